@@ -0,0 +1,142 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+// AddFeature adds a single Polygon or MultiPolygon with the given Location to
+// an existing Rgeo, e.g. to add custom geofences on top of the included
+// datasets without loading everything again. The dataset is the name it's
+// listed under in DatasetNames and found with in GetGeometry, if it's a new
+// name the dataset is added, otherwise the shape is added to it.
+//
+// The geometry is converted in the same way as when loading a dataset, with
+// the options given to New, but a geometry where every ring has no area gives
+// an error wrapping ErrDegenerateRing rather than being skipped. The Location
+// is used as it is, apart from WithFields, so WithFeatureFilter and
+// WithProperties don't apply.
+//
+// AddFeature isn't safe to call concurrently with any other method, including
+// the queries, as it changes the index and the maps they read. If you need to
+// add features while serving queries, guard the Rgeo with a sync.RWMutex,
+// taking the write lock for AddFeature and the read lock for everything else.
+// Each call builds a new index with all of the shapes, so one returned by
+// ShapeIndex before it won't have the new shape. The grid used by
+// NearestLocation and friends is also rebuilt the next time it's needed. This
+// makes adding a lot of features one at a time slow, they're much faster to
+// load as a dataset.
+func (r *Rgeo) AddFeature(geom orb.Geometry, loc Location, dataset string) error {
+	if dataset == "" {
+		return errors.New("missing parameter: dataset name")
+	}
+
+	p, err := polygonFromGeometry(geom, r.opts)
+	if err != nil {
+		return fmt.Errorf("bad polygon in geometry: %w", err)
+	}
+	if p.NumLoops() == 0 {
+		return fmt.Errorf("bad polygon in geometry: %w", ErrDegenerateRing)
+	}
+
+	// Adding to an index that's already been built deadlocks in the version of
+	// golang/geo used here (applying the update calls shrinkToFit, which takes
+	// the index lock again), so a new index is built with all of the shapes.
+	// They're added in the same order so their ids don't change.
+	index := s2.NewShapeIndex()
+	for i := int32(0); i < int32(r.index.Len()); i++ {
+		index.Add(r.index.Shape(i))
+	}
+	index.Add(p)
+	r.order[dataset] = append(r.order[dataset], p)
+
+	if r.keepsGeometry(dataset) {
+		r.datasetGeoms(dataset, 1)[p] = geom
+	} else {
+		r.datasetGeoms(dataset, 1)[p] = nil
+	}
+
+	if r.opts.countryMetadata != nil {
+		loc = r.opts.countryMetadata(loc)
+	}
+	if r.opts.fields != 0 {
+		loc = loc.only(r.opts.fields)
+	}
+	r.locs[p] = loc
+	r.addLevel(dataset, loc)
+
+	if r.opts.shapePreference != ShapeFirst {
+		r.areas[p] = p.Area()
+	}
+
+	r.setDatasetOptions(p, dataset)
+
+	containsPointQueryLock.Lock()
+	r.index = index
+	r.query = s2.NewContainsPointQuery(r.index, s2.VertexModelOpen)
+	r.vmQueries = nil
+	containsPointQueryLock.Unlock()
+
+	// The edge grid, city centres, neighbours and dissolved provinces are worked
+	// out again the next time they're needed.
+	r.edges = nil
+	r.edgesOnce = sync.Once{}
+	r.cities = nil
+	r.citiesOnce = sync.Once{}
+	r.neighboursMu.Lock()
+	r.neighbours = nil
+	r.neighboursMu.Unlock()
+	r.provincesMu.Lock()
+	r.provinces = nil
+	r.provincesMu.Unlock()
+
+	if r.opts.changed != nil {
+		r.opts.changed(dataset)
+	}
+
+	return nil
+}
+
+// WithDatasetChangeHook calls fn with the name of the dataset each time one is
+// changed after loading, so that caches of results outside of rgeo, like an
+// LRU cache in front of ReverseGeocode, can be cleared. rgeo doesn't have a way
+// to reload a dataset in place, a new Rgeo has to be made for that, so the
+// only change is adding a shape with AddFeature, including to a new dataset.
+// If it's used more than once all of the hooks are called, in the order they
+// were given. By default there are no hooks.
+//
+// The hook is called on the goroutine calling AddFeature, after the change is
+// complete and before AddFeature returns, so the new shape is already used by
+// every method. If AddFeature is guarded by a lock, as its documentation
+// suggests, that lock is still held, so the hook mustn't query the Rgeo
+// through it or it will deadlock. It should be quick, as AddFeature waits for
+// it.
+func WithDatasetChangeHook(fn func(dataset string)) Option {
+	return func(o *options) {
+		if prev := o.changed; prev != nil {
+			o.changed = func(dataset string) { prev(dataset); fn(dataset) }
+			return
+		}
+
+		o.changed = fn
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+func TestAddFeature(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, squareGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Build the edge grid, so it has to be rebuilt after adding.
+	if _, _, err := r.NearestLocation(orb.Point{5, 5}); err != nil {
+		t.Fatal(err)
+	}
+
+	fence := orb.Polygon{{{0.2, 52.2}, {0.4, 52.2}, {0.4, 52.4}, {0.2, 52.4}, {0.2, 52.2}}}
+	if err := r.AddFeature(fence, Location{City: "Fence"}, "fences"); err != nil {
+		t.Fatal(err)
+	}
+
+	far := orb.Polygon{{{10, 10}, {11, 10}, {11, 11}, {10, 11}, {10, 10}}}
+	if err := r.AddFeature(far, Location{City: "Far"}, "fences"); err != nil {
+		t.Fatal(err)
+	}
+
+	loc, err := r.ReverseGeocode(orb.Point{0.3, 52.3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(Location{CountryCode3: "TST", City: "Fence"}, loc); diff != nil {
+		t.Error(diff)
+	}
+
+	loc, _, err = r.NearestLocation(orb.Point{10.5, 11.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(Location{City: "Far"}, loc); diff != nil {
+		t.Error(diff)
+	}
+
+	g, err := r.GetGeometry(orb.Point{10.5, 10.5}, "fences")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(orb.Geometry(far), g); diff != nil {
+		t.Error(diff)
+	}
+
+	if names := r.DatasetNames(); len(names) != 2 || names[0] != "fences" {
+		t.Errorf("expected fences and the square dataset, got: %v", names)
+	}
+}
+
+func TestAddFeature_Errors(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, squareGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	square := orb.Polygon{{{5, 5}, {6, 5}, {6, 6}, {5, 6}, {5, 5}}}
+	if err := r.AddFeature(square, Location{}, ""); err == nil {
+		t.Error("expected error for empty dataset name")
+	}
+
+	if err := r.AddFeature(orb.Point{5, 5}, Location{}, "fences"); err == nil {
+		t.Error("expected error for point")
+	}
+
+	line := orb.Polygon{{{5, 5}, {6, 5}, {7, 5}, {5, 5}}}
+	if err := r.AddFeature(line, Location{}, "fences"); !errors.Is(err, ErrDegenerateRing) {
+		t.Errorf("expected error: %v\n got: %v\n", ErrDegenerateRing, err)
+	}
+}
+
+func TestWithDatasetChangeHook(t *testing.T) {
+	var (
+		r             *Rgeo
+		first, second []string
+	)
+	r, err := NewWithOptions([]func() []byte{func() []byte { return compressData(t, squareGeo) }},
+		WithDatasetChangeHook(func(dataset string) { first = append(first, dataset) }),
+		WithDatasetChangeHook(func(dataset string) {
+			second = append(second, dataset)
+
+			// The new shape is already used when the hook is called.
+			if _, err := r.ReverseGeocode(orb.Point{5.5, 5.5}); err != nil {
+				t.Errorf("expected the new shape to be found in the hook, got: %v", err)
+			}
+		}))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(first) != 0 {
+		t.Errorf("expected no changes from loading, got: %v", first)
+	}
+
+	square := orb.Polygon{{{5, 5}, {6, 5}, {6, 6}, {5, 6}, {5, 5}}}
+	if err := r.AddFeature(square, Location{City: "Fence"}, "fences"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Failed additions don't change anything.
+	if err := r.AddFeature(orb.Point{5, 5}, Location{}, "fences"); err == nil {
+		t.Error("expected error for point")
+	}
+
+	if diff := deep.Equal([]string{"fences"}, first); diff != nil {
+		t.Error(diff)
+	}
+	if diff := deep.Equal([]string{"fences"}, second); diff != nil {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"fmt"
+
+	"github.com/paulmach/orb"
+)
+
+// alternativesMeters is how close another country has to be for
+// ReverseGeocodeWithAlternatives to return it as an alternative.
+const alternativesMeters = 20000
+
+// ReverseGeocodeWithAlternatives returns the Location from ReverseGeocode,
+// followed by up to n alternatives: the Locations of the closest shapes of
+// other countries within 20km of the coordinate, e.g. Belgium for a point in
+// France near the border. This is for showing "likely France, possibly
+// Belgium", as the included datasets are simplified and a point near a border
+// can be on the wrong side of it.
+//
+// The alternatives are ordered by distance, closest first, with shapes which
+// also contain the point (from overlapping or disputed shapes) at a distance of
+// 0, and ties in the order the shapes were loaded. Each Country is only given
+// once, as the Location of its closest shape, and shapes without a Country are
+// skipped. If the coordinate isn't inside any shape it returns
+// ErrLocationNotFound, use NearestMatches to look for shapes around points
+// which aren't.
+func (r *Rgeo) ReverseGeocodeWithAlternatives(loc orb.Point, n int) ([]Location, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("invalid number of alternatives: %d", n)
+	}
+
+	primary, err := r.reverseGeocode(pointFromCoord(loc))
+	if err != nil {
+		return nil, err
+	}
+
+	ret := []Location{primary}
+	if n == 0 {
+		return ret, nil
+	}
+
+	// The point is in a shape, so there's always at least one match.
+	matches, err := r.NearestMatches(loc, alternativesMeters)
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]bool{primary.Country: true}
+	for _, m := range matches {
+		if len(ret) > n {
+			break
+		}
+		if m.Country == "" || seen[m.Country] {
+			continue
+		}
+
+		seen[m.Country] = true
+		ret = append(ret, m.Location)
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+func TestReverseGeocodeWithAlternatives(t *testing.T) {
+	threeGeo := `{
+		"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"ADMIN":"West"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}},
+			{"type":"Feature",
+			"properties":{"ADMIN":"East"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[1,0],[2,0],[2,1],[1,1],[1,0]]]}},
+			{"type":"Feature",
+			"properties":{"ADMIN":"North"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,1],[2,1],[2,2],[0,2],[0,1]]]}}
+		]
+	}`
+
+	r, err := New(func() []byte { return compressData(t, threeGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	west := Location{Country: "West"}
+	east := Location{Country: "East"}
+	north := Location{Country: "North"}
+
+	tests := []struct {
+		name     string
+		in       orb.Point
+		n        int
+		expected []Location
+		err      error
+	}{
+		// East is around 5.5km away and North around 11km.
+		{"All", orb.Point{0.95, 0.9}, 5, []Location{west, east, north}, nil},
+		{"Closest", orb.Point{0.95, 0.9}, 1, []Location{west, east}, nil},
+		{"None", orb.Point{0.95, 0.9}, 0, []Location{west}, nil},
+		{"TooFar", orb.Point{0.5, 0.5}, 5, []Location{west}, nil},
+		{"Miss", orb.Point{5, 5}, 5, nil, ErrLocationNotFound},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			locs, err := r.ReverseGeocodeWithAlternatives(test.in, test.n)
+			if !errors.Is(err, test.err) {
+				t.Errorf("expected error: %v\n got: %v\n", test.err, err)
+			}
+			if diff := deep.Equal(test.expected, locs); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+
+	if _, err := r.ReverseGeocodeWithAlternatives(orb.Point{0.5, 0.5}, -1); err == nil {
+		t.Error("expected error for negative n")
+	}
+}
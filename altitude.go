@@ -0,0 +1,111 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+// altitudeRange is the range of altitudes a shape covers, in meters, an
+// infinite end leaves it open.
+type altitudeRange struct {
+	min, max float64
+}
+
+// contains reports whether alt is in the range, which includes min but not
+// max.
+func (a altitudeRange) contains(alt float64) bool {
+	return alt >= a.min && alt < a.max
+}
+
+// WithAltitudeProperties reads the altitude range of each shape from the given
+// GeoJSON properties, for ReverseGeocode3D, e.g. for airspace sectors which
+// stack on top of each other. The values are in meters, as numbers or strings
+// holding numbers, and the range includes the minimum but not the maximum, so
+// a sector from 0 to 3000 and one from 3000 upwards don't both match at 3000. A
+// shape with only one of them set is open on the other end, and a shape with
+// neither covers every altitude. A value which isn't a number makes loading
+// fail.
+//
+// Shapes added with AddFeature don't have an altitude range.
+func WithAltitudeProperties(minKey, maxKey string) Option {
+	return func(o *options) {
+		o.altitudeKeys = []string{minKey, maxKey}
+	}
+}
+
+// getAltitudeRange reads the altitude range from the properties named in keys,
+// the minimum and then the maximum. ok is false if neither is set.
+func getAltitudeRange(props map[string]interface{}, keys []string) (a altitudeRange, ok bool, err error) {
+	a = altitudeRange{min: math.Inf(-1), max: math.Inf(1)}
+	for i, end := range []*float64{&a.min, &a.max} {
+		var v float64
+		switch p := props[keys[i]].(type) {
+		case nil:
+			continue
+		case float64:
+			v = p
+		case string:
+			if v, err = strconv.ParseFloat(p, 64); err != nil {
+				return altitudeRange{}, false, fmt.Errorf("bad altitude property %q: %w", keys[i], err)
+			}
+		default:
+			return altitudeRange{}, false, fmt.Errorf("bad altitude property %q: %v", keys[i], p)
+		}
+
+		if math.IsNaN(v) {
+			return altitudeRange{}, false, fmt.Errorf("bad altitude property %q: %v", keys[i], v)
+		}
+
+		*end, ok = v, true
+	}
+
+	return a, ok, nil
+}
+
+// ReverseGeocode3D works like ReverseGeocode for the coordinate lon, lat, but
+// only uses the shapes whose altitude range, read with WithAltitudeProperties,
+// includes alt, which is in meters. Shapes without an altitude range, such as
+// all of those from the included datasets, match at any altitude, so alt is
+// ignored for them. The other methods all ignore altitude ranges.
+//
+// If shapes from different altitude ranges contain the point they're combined
+// just as they are by ReverseGeocode.
+func (r *Rgeo) ReverseGeocode3D(lon, lat, alt float64) (Location, error) {
+	if math.IsNaN(alt) {
+		return Location{}, fmt.Errorf("invalid altitude: %v", alt)
+	}
+
+	res := r.containingShapes(pointFromCoord(orb.Point{lon, lat}))
+
+	kept := make([]s2.Shape, 0, len(res))
+	for _, shape := range res {
+		if a, ok := r.altitudes[shape]; !ok || a.contains(alt) {
+			kept = append(kept, shape)
+		}
+	}
+
+	if len(kept) == 0 {
+		return Location{}, ErrLocationNotFound
+	}
+
+	return r.combineLocations(kept), nil
+}
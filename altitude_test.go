@@ -0,0 +1,100 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestReverseGeocode3D(t *testing.T) {
+	sectorsGeo := `{
+		"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"ADMIN":"Low","MIN_ALT":0,"MAX_ALT":3000},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}},
+			{"type":"Feature",
+			"properties":{"ADMIN":"High","MIN_ALT":"3000"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}}
+		]
+	}`
+	sectors := func() []byte { return compressData(t, sectorsGeo) }
+	square := func() []byte { return compressData(t, squareGeo) }
+
+	r, err := NewWithOptions([]func() []byte{sectors, square}, WithAltitudeProperties("MIN_ALT", "MAX_ALT"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name          string
+		lon, lat, alt float64
+		expected      Location
+		err           error
+	}{
+		{"Low", 0.5, 0.5, 100, Location{Country: "Low"}, nil},
+		{"Boundary", 0.5, 0.5, 3000, Location{Country: "High"}, nil},
+		{"OpenMax", 0.5, 0.5, 1e6, Location{Country: "High"}, nil},
+		{"Below", 0.5, 0.5, -10, Location{}, ErrLocationNotFound},
+		{"NoAltitude", 0.5, 52.5, 1e6, Location{CountryCode3: "TST"}, nil},
+		{"Miss", 5, 5, 100, Location{}, ErrLocationNotFound},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			loc, err := r.ReverseGeocode3D(test.lon, test.lat, test.alt)
+			if !errors.Is(err, test.err) {
+				t.Errorf("expected error: %v\n got: %v\n", test.err, err)
+			}
+			if diff := deep.Equal(test.expected, loc); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+
+	if _, err := r.ReverseGeocode3D(0.5, 0.5, math.NaN()); err == nil {
+		t.Error("expected error for NaN altitude")
+	}
+
+	// Without the option the altitudes aren't read, so both sectors match.
+	plain, err := New(sectors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loc, err := plain.ReverseGeocode3D(0.5, 0.5, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(Location{Country: "Low"}, loc); diff != nil {
+		t.Error(diff)
+	}
+
+	bad := func() []byte {
+		return compressData(t, `{"type":"FeatureCollection","features":[{"type":"Feature",
+			"properties":{"MIN_ALT":"high"},
+			"geometry":{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}}]}`)
+	}
+	if _, err := NewWithOptions([]func() []byte{bad}, WithAltitudeProperties("MIN_ALT", "MAX_ALT")); err == nil {
+		t.Error("expected error for a bad altitude")
+	}
+}
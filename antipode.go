@@ -0,0 +1,48 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"math"
+
+	"github.com/paulmach/orb"
+)
+
+// Antipode returns the Location of the point on the opposite side of the Earth
+// from loc, i.e. where you'd come out if you dug straight down. It returns
+// ErrLocationNotFound if that's in the sea, which it usually is.
+func (r *Rgeo) Antipode(loc orb.Point) (Location, error) {
+	return r.ReverseGeocode(antipode(loc))
+}
+
+// antipode returns the point opposite loc, with the longitude wrapped to
+// (-180, 180].
+func antipode(loc orb.Point) orb.Point {
+	lon := math.Mod(loc[0]+180, 360)
+	if lon > 180 {
+		lon -= 360
+	} else if lon <= -180 {
+		lon += 360
+	}
+
+	lat := -loc[1]
+	if lat == 0 {
+		// Avoid -0, which prints differently to 0.
+		lat = 0
+	}
+
+	return orb.Point{lon, lat}
+}
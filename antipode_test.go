@@ -0,0 +1,60 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"testing"
+
+	"github.com/paulmach/orb"
+)
+
+func TestAntipodePoint(t *testing.T) {
+	tests := []struct {
+		in, expected orb.Point
+	}{
+		{orb.Point{0, 0}, orb.Point{180, 0}},
+		{orb.Point{180, 0}, orb.Point{0, 0}},
+		{orb.Point{-180, 0}, orb.Point{0, 0}},
+		{orb.Point{90, 45}, orb.Point{-90, -45}},
+		{orb.Point{-90, -45}, orb.Point{90, 45}},
+		{orb.Point{-3.5, 40}, orb.Point{176.5, -40}},
+		{orb.Point{179.5, 10}, orb.Point{-0.5, -10}},
+		{orb.Point{-179.5, 10}, orb.Point{0.5, -10}},
+		{orb.Point{540, 90}, orb.Point{0, -90}},
+	}
+
+	for _, test := range tests {
+		if got := antipode(test.in); got != test.expected {
+			t.Errorf("%v: expected: %v\n got: %v\n", test.in, test.expected, got)
+		}
+	}
+}
+
+func TestAntipode(t *testing.T) {
+	r, err := New(Countries110)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Madrid is opposite New Zealand.
+	loc, err := r.Antipode(orb.Point{-3.7, 40.4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loc.Country != "New Zealand" {
+		t.Errorf("expected: New Zealand\n got: %s\n", loc.Country)
+	}
+}
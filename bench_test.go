@@ -0,0 +1,291 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+// The inputs for the benchmarks are fixed, rather than random like the older
+// benchmarks, so that runs can be compared with benchstat.
+var (
+	// benchHits are city centres, which are inside a country in Countries10.
+	benchHits = []orb.Point{
+		{-0.1276, 51.5072},   // London
+		{2.3522, 48.8566},    // Paris
+		{-74.006, 40.7128},   // New York
+		{139.6917, 35.6895},  // Tokyo
+		{-46.6333, -23.5505}, // São Paulo
+		{31.2357, 30.0444},   // Cairo
+		{37.6173, 55.7558},   // Moscow
+		{72.8777, 19.076},    // Mumbai
+		{-99.1332, 19.4326},  // Mexico City
+		{3.3792, 6.5244},     // Lagos
+		{116.4074, 39.9042},  // Beijing
+		{151.2093, -33.8688}, // Sydney
+	}
+
+	// benchMisses are in the middle of the oceans, far from any shape.
+	benchMisses = []orb.Point{
+		{-140, 0},
+		{-30, 30},
+		{80, -30},
+		{160, 30},
+		{0, -60},
+		{-150, -45},
+		{65, 10},
+		{-25, -20},
+	}
+
+	// benchBorders are within a few kilometers of a border or the coast, where
+	// the most edges have to be checked.
+	benchBorders = []orb.Point{
+		{7.5886, 47.5596}, // Basel, France, Germany and Switzerland
+		{-117.03, 32.54},  // Tijuana and San Diego
+		{8.19, 48.98},     // Lauterbourg, France and Germany
+		{4.93, 51.44},     // Baarle-Nassau, Belgium and the Netherlands
+		{-79.07, 43.09},   // Niagara Falls, Canada and the USA
+		{1.73, 42.47},     // Andorra, France and Spain
+		{-5.35, 36.15},    // Gibraltar and Spain
+		{-70.67, -55.0},   // Tierra del Fuego, Argentina and Chile
+	}
+)
+
+// benchRgeo is loaded once, as loading Countries10 takes longer than most of
+// the benchmarks.
+var benchRgeo = struct {
+	once sync.Once
+	r    *Rgeo
+	err  error
+}{}
+
+func loadBenchRgeo(b *testing.B) *Rgeo {
+	b.Helper()
+
+	benchRgeo.once.Do(func() {
+		benchRgeo.r, benchRgeo.err = New(Countries10)
+	})
+	if benchRgeo.err != nil {
+		b.Fatal(benchRgeo.err)
+	}
+
+	return benchRgeo.r
+}
+
+func BenchmarkNewDatasets(b *testing.B) {
+	datasets := []struct {
+		name string
+		data func() []byte
+	}{
+		{"Countries110", Countries110},
+		{"Countries10", Countries10},
+		{"Provinces10", Provinces10},
+		{"US_Counties10", US_Counties10},
+		{"Cities10", Cities10},
+	}
+
+	for _, ds := range datasets {
+		ds := ds
+		b.Run(ds.name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := New(ds.data); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkReverseGeocodeInputs(b *testing.B) {
+	r := loadBenchRgeo(b)
+
+	for _, p := range benchHits {
+		if _, err := r.ReverseGeocode(p); err != nil {
+			b.Fatalf("expected a hit for %v: %v", p, err)
+		}
+	}
+
+	for _, p := range benchMisses {
+		if _, err := r.ReverseGeocode(p); !errors.Is(err, ErrLocationNotFound) {
+			b.Fatalf("expected a miss for %v: %v", p, err)
+		}
+	}
+
+	inputs := []struct {
+		name   string
+		points []orb.Point
+	}{
+		{"Hits", benchHits},
+		{"Misses", benchMisses},
+		{"Borders", benchBorders},
+	}
+
+	for _, in := range inputs {
+		in := in
+		b.Run(in.name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				_, _ = r.ReverseGeocode(in.points[i%len(in.points)])
+			}
+		})
+
+		// The core skips converting the coordinate to an s2.Point, which
+		// shows how much of the time is spent in the index.
+		points := make([]s2.Point, len(in.points))
+		for i, p := range in.points {
+			points[i] = pointFromCoord(p)
+		}
+
+		b.Run(in.name+"Core", func(b *testing.B) {
+			b.ReportAllocs()
+
+			for i := 0; i < b.N; i++ {
+				_, _ = r.reverseGeocode(points[i%len(points)])
+			}
+		})
+	}
+}
+
+func BenchmarkBatch(b *testing.B) {
+	r := loadBenchRgeo(b)
+
+	points := make([]orb.Point, 0, len(benchHits)+len(benchMisses)+len(benchBorders))
+	points = append(points, benchHits...)
+	points = append(points, benchMisses...)
+	points = append(points, benchBorders...)
+
+	b.Run("Serial", func(b *testing.B) {
+		b.ReportAllocs()
+
+		for i := 0; i < b.N; i++ {
+			_, _ = r.ReverseGeocode(points[i%len(points)])
+		}
+	})
+
+	b.Run("Parallel", func(b *testing.B) {
+		b.ReportAllocs()
+
+		b.RunParallel(func(pb *testing.PB) {
+			for i := 0; pb.Next(); i++ {
+				_, _ = r.ReverseGeocode(points[i%len(points)])
+			}
+		})
+	})
+
+	b.Run("Pool", func(b *testing.B) {
+		b.ReportAllocs()
+
+		pool := NewPool(r, 8)
+		defer pool.Close()
+
+		results := make([]<-chan Result, 0, len(points))
+		for i := 0; i < b.N; i++ {
+			results = append(results, pool.Submit(points[i%len(points)]))
+
+			if len(results) == cap(results) {
+				for _, res := range results {
+					<-res
+				}
+				results = results[:0]
+			}
+		}
+
+		for _, res := range results {
+			<-res
+		}
+	})
+}
+
+// BenchmarkLoading compares the ways of loading Countries10 and Provinces10
+// together, reporting the size of the index along with the time.
+func BenchmarkLoading(b *testing.B) {
+	datasets := []func() []byte{Countries10, Provinces10}
+
+	modes := []struct {
+		name string
+		opts []Option
+	}{
+		{"Naive", nil},
+		{"Concurrent", []Option{WithConcurrentLoading()}},
+		{"Dedup", []Option{DedupCountries()}},
+		{"ConcurrentDedup", []Option{WithConcurrentLoading(), DedupCountries()}},
+	}
+
+	for _, mode := range modes {
+		mode := mode
+		b.Run(mode.name, func(b *testing.B) {
+			b.ReportAllocs()
+
+			var r *Rgeo
+			for i := 0; i < b.N; i++ {
+				var err error
+				if r, err = NewWithOptions(datasets, mode.opts...); err != nil {
+					b.Fatal(err)
+				}
+			}
+
+			edges := 0
+			for i := 0; i < r.index.Len(); i++ {
+				edges += r.index.Shape(int32(i)).NumEdges()
+			}
+
+			b.ReportMetric(float64(r.index.Len()), "shapes")
+			b.ReportMetric(float64(edges), "edges")
+		})
+	}
+}
+
+func BenchmarkCombineLocations(b *testing.B) {
+	single := loadBenchRgeo(b)
+
+	multiple, err := New(Countries10, Provinces10)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	paris := pointFromCoord(orb.Point{2.3522, 48.8566})
+
+	for _, bm := range []struct {
+		name string
+		r    *Rgeo
+	}{
+		{"Single", single},
+		{"Multiple", multiple},
+	} {
+		bm := bm
+		b.Run(bm.name, func(b *testing.B) {
+			shapes := bm.r.containingShapes(paris)
+			if len(shapes) == 0 {
+				b.Fatal("no shapes contain the point")
+			}
+
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				bm.r.combineLocations(shapes)
+			}
+		})
+	}
+}
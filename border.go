@@ -0,0 +1,55 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+// borderToleranceMeters is how close a point has to be to the edge of a shape
+// for ReverseGeocodeBorder to count it as on the border.
+const borderToleranceMeters = 0.01
+
+// ReverseGeocodeBorder returns the Locations of all of the shapes whose border
+// the given coordinate lies on, e.g. both countries for a point on the border
+// between them, for detecting border crossings or checking data. Duplicate
+// Locations are only returned once, in the order the shapes were loaded, and if
+// the coordinate isn't on any border it returns ErrLocationNotFound.
+//
+// ReverseGeocode gives a point on a shared border to exactly one of the shapes,
+// or none of them if it's on a vertex. Using a different s2 vertex model
+// wouldn't help with this, as it only changes which shapes contain their
+// vertices, so this looks for edges close to the point instead.
+//
+// A coordinate counts as on the border if it's within 1cm of it. Coordinates
+// are float64 degrees, which gets lost in the conversion to s2 points at
+// around the nanometre scale, so points computed to lie exactly on a border
+// (e.g. the midpoint of an edge) are found, but ones which are rounded, e.g.
+// to 7 decimal places or around 1cm, might not be.
+func (r *Rgeo) ReverseGeocodeBorder(loc orb.Point) ([]Location, error) {
+	ids := r.edgeGrid().within(pointFromCoord(loc), chordAngleFromMeters(borderToleranceMeters))
+	if len(ids) == 0 {
+		return nil, ErrLocationNotFound
+	}
+
+	found := make(map[s2.Shape]bool, len(ids))
+	for _, id := range ids {
+		found[r.index.Shape(id)] = true
+	}
+
+	return r.uniqueLocations(r.inLoadOrder(found)), nil
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+// neighboursGeo is two 1x1 degree squares sharing the meridian at 1°E as
+// their border.
+const neighboursGeo = `{
+	"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"ADMIN":"West"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}},
+			{"type":"Feature",
+			"properties":{"ADMIN":"East"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[1,0],[2,0],[2,1],[1,1],[1,0]]]}}
+		]
+	}`
+
+func TestReverseGeocodeBorder(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, neighboursGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	west := Location{Country: "West"}
+	east := Location{Country: "East"}
+
+	tests := []struct {
+		name     string
+		in       orb.Point
+		expected []Location
+		err      error
+	}{
+		{name: "Shared edge", in: orb.Point{1, 0.5}, expected: []Location{west, east}},
+		{name: "Shared vertex", in: orb.Point{1, 1}, expected: []Location{west, east}},
+		{name: "Outer edge", in: orb.Point{0, 0.5}, expected: []Location{west}},
+		{name: "Inside", in: orb.Point{0.5, 0.5}, err: ErrLocationNotFound},
+		{name: "Just off the border", in: orb.Point{1.000001, 0.5}, err: ErrLocationNotFound},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := r.ReverseGeocodeBorder(test.in)
+			if !errors.Is(err, test.err) {
+				t.Errorf("expected error: %v\n got: %v\n", test.err, err)
+			}
+			if diff := deep.Equal(test.expected, got); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
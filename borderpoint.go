@@ -0,0 +1,106 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/golang/geo/s1"
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+// NearestBorderPoint returns the point on the border of the shapes in the given
+// dataset which is closest to loc, and the distance to it in meters, e.g. to
+// draw a line to the nearest border or snap a point onto it. Unlike
+// NearestLocation, points inside a shape aren't 0 away, it gives the distance to
+// the border of the shape they're in. The borders include the edges of holes,
+// and of shapes inside other shapes.
+//
+// The dataset is one of the names returned by DatasetNames. Distances are
+// measured on a sphere, as in NearestLocation, and the point is on the great
+// circle edge of the shape, so it can be slightly off the straight line
+// between the shape's vertices drawn on a map.
+func (r *Rgeo) NearestBorderPoint(loc orb.Point, dataset string) (orb.Point, float64, error) {
+	_, q, dist, err := r.nearestBorderPoint(loc, dataset)
+	if err != nil {
+		return orb.Point{}, 0, err
+	}
+
+	ll := s2.LatLngFromPoint(q)
+
+	return orb.Point{ll.Lng.Degrees(), ll.Lat.Degrees()}, metersFromChordAngle(dist), nil
+}
+
+// BearingToBorder returns the initial bearing in degrees from loc to the point
+// on the border of the shapes in the given dataset which is closest to it, as
+// found by NearestBorderPoint, e.g. for a hint of which way to go to reach the
+// border. The bearing is a compass bearing, clockwise from true north, so 0 is
+// north and 90 is east, in the range [0, 360). It's the direction at the start
+// of the great circle path to the border point, which can differ from the
+// direction of the straight line drawn on a map, most of all far from the
+// equator. If loc is on the border the bearing is 0.
+func (r *Rgeo) BearingToBorder(loc orb.Point, dataset string) (float64, error) {
+	p, q, _, err := r.nearestBorderPoint(loc, dataset)
+	if err != nil {
+		return 0, err
+	}
+
+	return initialBearing(s2.LatLngFromPoint(p), s2.LatLngFromPoint(q)), nil
+}
+
+// nearestBorderPoint returns loc as an s2 Point, the closest point to it on the
+// border of the shapes in the dataset, and the distance between them.
+func (r *Rgeo) nearestBorderPoint(loc orb.Point, dataset string) (p, q s2.Point, dist s1.ChordAngle, err error) {
+	if dataset == "" {
+		return p, q, 0, errors.New("missing parameter: dataset")
+	}
+
+	shpGeoms, ok := r.geoms[dataset]
+	if !ok {
+		return p, q, 0, fmt.Errorf("dataset not found: %q (have %v)", dataset, r.DatasetNames())
+	}
+
+	keep := func(id int32) bool {
+		_, ok := shpGeoms[r.index.Shape(id)]
+		return ok
+	}
+
+	p = pointFromCoord(loc)
+
+	ref, dist, ok := r.edgeGrid().nearestEdge(p, s1.InfChordAngle(), keep)
+	if !ok {
+		return p, q, 0, ErrLocationNotFound
+	}
+
+	e := r.index.Shape(ref.shape).Edge(int(ref.edge))
+
+	return p, s2.Project(p, e.V0, e.V1), dist, nil
+}
+
+// initialBearing returns the compass bearing in degrees at a of the great
+// circle from a to b.
+func initialBearing(a, b s2.LatLng) float64 {
+	dLng := (b.Lng - a.Lng).Radians()
+	lat1, lat2 := a.Lat.Radians(), b.Lat.Radians()
+
+	y := math.Sin(dLng) * math.Cos(lat2)
+	x := math.Cos(lat1)*math.Sin(lat2) - math.Sin(lat1)*math.Cos(lat2)*math.Cos(dLng)
+
+	return math.Mod(math.Atan2(y, x)*180/math.Pi+360, 360)
+}
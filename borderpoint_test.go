@@ -0,0 +1,117 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/geojson"
+)
+
+func TestNearestBorderPoint(t *testing.T) {
+	fc, err := geojson.UnmarshalFeatureCollection([]byte(squareGeo))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewFromFeatureCollection(fc, "square")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The top edge is a great circle, so it bulges slightly north of 53 in the
+	// middle.
+	tests := []struct {
+		name     string
+		in       orb.Point
+		expected orb.Point
+		dist     float64
+	}{
+		{"North", orb.Point{0.5, 54}, orb.Point{0.5, 53}, 111195},
+		{"InsideNearTop", orb.Point{0.5, 52.9}, orb.Point{0.5, 53}, 11119.5},
+		{"West", orb.Point{-0.5, 52.5}, orb.Point{0, 52.5}, 33850},
+		{"Corner", orb.Point{1.5, 51.5}, orb.Point{1, 52}, 66160},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			p, dist, err := r.NearestBorderPoint(test.in, "square")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(p[0]-test.expected[0]) > 0.01 || math.Abs(p[1]-test.expected[1]) > 0.01 {
+				t.Errorf("expected point: %v, got: %v", test.expected, p)
+			}
+			if math.Abs(dist-test.dist) > test.dist*0.02 {
+				t.Errorf("expected distance: %f, got: %f", test.dist, dist)
+			}
+		})
+	}
+
+	if _, _, err := r.NearestBorderPoint(orb.Point{0, 0}, "other"); err == nil {
+		t.Error("expected error for unknown dataset")
+	}
+	if _, _, err := r.NearestBorderPoint(orb.Point{0, 0}, ""); err == nil {
+		t.Error("expected error for empty dataset")
+	}
+}
+
+func TestBearingToBorder(t *testing.T) {
+	fc, err := geojson.UnmarshalFeatureCollection([]byte(squareGeo))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewFromFeatureCollection(fc, "square")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		in       orb.Point
+		expected float64
+	}{
+		{"North", orb.Point{0.5, 54}, 180},
+		{"InsideNearTop", orb.Point{0.5, 52.9}, 0},
+		{"West", orb.Point{-0.5, 52.5}, 90},
+		{"Corner", orb.Point{1.5, 51.5}, 328.4},
+		{"OnVertex", orb.Point{0, 52}, 0},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			bearing, err := r.BearingToBorder(test.in, "square")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if bearing < 0 || bearing >= 360 {
+				t.Errorf("bearing out of range: %v", bearing)
+			}
+			if d := math.Abs(bearing - test.expected); math.Min(d, 360-d) > 0.5 {
+				t.Errorf("expected bearing: %v, got: %v", test.expected, bearing)
+			}
+		})
+	}
+
+	if _, err := r.BearingToBorder(orb.Point{0, 0}, "other"); err == nil {
+		t.Error("expected error for unknown dataset")
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+// CapOf returns a bounding cap of the shape from the given dataset which
+// contains the coordinate, that is a circle on the sphere which the whole
+// shape is inside, for spatial algorithms which work with circles rather than
+// rectangles. It returns ErrLocationNotFound if no shape contains the
+// coordinate.
+//
+// The cap is the one from s2.Polygon.CapBound, which is quick to work out but
+// isn't the smallest possible, it can be a little larger. For MultiPolygons it
+// covers all of the polygons, so e.g. France's includes French Guiana and the
+// other overseas regions. The radius is an angle, multiply its Radians by
+// 6371008.8 for meters.
+func (r *Rgeo) CapOf(loc orb.Point, dataset string) (s2.Cap, error) {
+	shape, _, err := r.datasetShape(loc, dataset)
+	if err != nil {
+		return s2.EmptyCap(), err
+	}
+
+	if p, ok := shape.(*s2.Polygon); ok {
+		return p.CapBound(), nil
+	}
+
+	return s2.EmptyCap(), ErrLocationNotFound
+}
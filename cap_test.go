@@ -0,0 +1,56 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/paulmach/orb"
+)
+
+func TestCapOf(t *testing.T) {
+	r := newTwoSquares(t)
+
+	c, err := r.CapOf(orb.Point{0.5, 0.5}, "squares")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The cap contains the whole square but not its neighbour.
+	for _, p := range []orb.Point{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0.5, 0.5}} {
+		if !c.ContainsPoint(pointFromCoord(p)) {
+			t.Errorf("expected the cap to contain %v", p)
+		}
+	}
+	if c.ContainsPoint(pointFromCoord(orb.Point{2.5, 0.5})) {
+		t.Error("expected the cap not to contain the other square")
+	}
+
+	// The half diagonal of the square is about 0.71 degrees, the bound can be
+	// a little larger.
+	if deg := c.Radius().Degrees(); deg < 0.7 || deg > 1 {
+		t.Errorf("expected a radius of around 0.71 degrees, got: %f", deg)
+	}
+
+	if _, err := r.CapOf(orb.Point{1.5, 0.5}, "squares"); !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("expected error: %v\n got: %v\n", ErrLocationNotFound, err)
+	}
+
+	if _, err := r.CapOf(orb.Point{0.5, 0.5}, "missing"); err == nil {
+		t.Error("expected error for missing dataset")
+	}
+}
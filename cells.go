@@ -0,0 +1,87 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"fmt"
+
+	"github.com/golang/geo/s2"
+)
+
+// CellMatch is how LocationsForCells matches the shapes to each cell.
+type CellMatch int
+
+const (
+	// CellCentre gives each cell the Location of its centre, as from
+	// ReverseGeocode. It's the fastest, but a cell on a border gets the
+	// Location of whichever side its centre is on.
+	CellCentre CellMatch = iota
+
+	// CellContained only uses the shapes which contain the whole cell, so a
+	// cell on a border only gets the fields of the shapes it's completely
+	// inside, e.g. just the country for a cell across a provincial border,
+	// and nothing for one across a national border or the coast.
+	CellContained
+)
+
+// LocationsForCells returns the Location of each of the given s2 cells, e.g.
+// for workers which are each given ranges of cells to process. The cells can
+// be at any level, and don't have to be normalised. How the shapes are
+// matched to each cell is set by match, see CellMatch.
+//
+// Cells which don't match any shape are left out of the map, and it returns
+// ErrLocationNotFound if none of them do. Any invalid cell gives an error.
+func (r *Rgeo) LocationsForCells(cells s2.CellUnion, match CellMatch) (map[s2.CellID]Location, error) {
+	if match != CellCentre && match != CellContained {
+		return nil, fmt.Errorf("invalid cell match: %d", match)
+	}
+
+	locs := make(map[s2.CellID]Location, len(cells))
+	for _, id := range cells {
+		if !id.IsValid() {
+			return nil, fmt.Errorf("invalid cell: %v", id)
+		}
+		if _, ok := locs[id]; ok {
+			continue
+		}
+
+		if match == CellCentre {
+			if l, err := r.reverseGeocode(id.Point()); err == nil {
+				locs[id] = l
+			}
+			continue
+		}
+
+		// A shape which contains the whole cell has to contain its centre.
+		cell := s2.CellFromCellID(id)
+		var in []s2.Shape
+		for _, shape := range r.containingShapes(id.Point()) {
+			if p, ok := shape.(*s2.Polygon); ok && p.ContainsCell(cell) {
+				in = append(in, shape)
+			}
+		}
+
+		if len(in) > 0 {
+			locs[id] = r.combineLocations(in)
+		}
+	}
+
+	if len(locs) == 0 {
+		return nil, ErrLocationNotFound
+	}
+
+	return locs, nil
+}
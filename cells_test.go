@@ -0,0 +1,74 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/golang/geo/s2"
+)
+
+func TestLocationsForCells(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, levelsGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cellAt := func(lat, lng float64, level int) s2.CellID {
+		return s2.CellIDFromLatLng(s2.LatLngFromDegrees(lat, lng)).Parent(level)
+	}
+
+	land := Location{Country: "Land", CountryCode3: "LND"}
+	north := Location{Country: "Land", CountryCode3: "LND", Province: "North", ProvinceCode: "LND-N"}
+
+	inside := cellAt(0.5, 0.5, 15)
+	// This cell crosses the border of North at latitude 2, with its centre
+	// inside it.
+	border := cellAt(2, 1.5, 7)
+	outside := cellAt(10, 10, 15)
+
+	cells := s2.CellUnion{inside, border, outside}
+
+	tests := []struct {
+		match    CellMatch
+		expected map[s2.CellID]Location
+	}{
+		{CellCentre, map[s2.CellID]Location{inside: land, border: north}},
+		{CellContained, map[s2.CellID]Location{inside: land, border: land}},
+	}
+
+	for _, test := range tests {
+		locs, err := r.LocationsForCells(cells, test.match)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := deep.Equal(test.expected, locs); diff != nil {
+			t.Errorf("match %d: %v", test.match, diff)
+		}
+	}
+
+	if _, err := r.LocationsForCells(s2.CellUnion{outside}, CellCentre); !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("expected ErrLocationNotFound, got %v", err)
+	}
+	if _, err := r.LocationsForCells(s2.CellUnion{s2.CellID(0)}, CellCentre); err == nil {
+		t.Error("expected error for invalid cell")
+	}
+	if _, err := r.LocationsForCells(cells, CellMatch(5)); err == nil {
+		t.Error("expected error for invalid match")
+	}
+}
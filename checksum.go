@@ -0,0 +1,46 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import "strings"
+
+// datasetChecksums holds the checksums written by datagen for the included
+// datasets, by the name of their function.
+var datasetChecksums = map[string]*string{
+	"Cities10":      &cities10Sum,
+	"Countries10":   &countries10Sum,
+	"Countries110":  &countries110Sum,
+	"Provinces10":   &provinces10Sum,
+	"US_Counties10": &us_counties10Sum,
+}
+
+// DatasetChecksum returns the checksum of one of the included datasets, for
+// checking which version of the data a binary was built with. The name is the
+// name of the dataset function, either on its own (e.g. "Countries10") or as
+// returned by DatasetNames (e.g. "github.com/sams96/rgeo.Countries10"). ok is
+// false for any other name.
+//
+// The checksum is the hex encoded SHA-256 of the compressed data returned by
+// the dataset function, as written alongside it by datagen when the dataset
+// was generated.
+func DatasetChecksum(name string) (sum string, ok bool) {
+	s, ok := datasetChecksums[strings.TrimPrefix(name, "github.com/sams96/rgeo.")]
+	if !ok {
+		return "", false
+	}
+
+	return *s, true
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"testing"
+)
+
+func TestDatasetChecksum(t *testing.T) {
+	for _, dataset := range []func() []byte{Cities10, Countries10, Countries110, Provinces10, US_Counties10} {
+		name := getFunctionName(dataset)
+
+		sum, ok := DatasetChecksum(name)
+		if !ok {
+			t.Errorf("%s: no checksum", name)
+			continue
+		}
+
+		got := sha256.Sum256(dataset())
+		if expected := hex.EncodeToString(got[:]); sum != expected {
+			t.Errorf("%s: expected checksum: %s\n got: %s\n", name, expected, sum)
+		}
+	}
+
+	if sum, ok := DatasetChecksum("Countries110"); !ok || len(sum) != sha256.Size*2 {
+		t.Errorf("expected a checksum for the short name, got: %q, %v", sum, ok)
+	}
+	if _, ok := DatasetChecksum("Countries50"); ok {
+		t.Error("expected no checksum for an unknown dataset")
+	}
+}
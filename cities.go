@@ -0,0 +1,148 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+// CityPreference decides which city is returned when a coordinate is inside
+// more than one city shape, which happens in Cities10 where urban areas
+// overlap.
+type CityPreference int
+
+const (
+	// CityFirst returns the first city shape found, this is the default.
+	CityFirst CityPreference = iota
+
+	// CityLargestPopulation returns the city with the largest population, read
+	// from the max_pop_al property in Cities10 or pop_max/POP_EST in other
+	// datasets. Cities without a population count as 0.
+	CityLargestPopulation
+
+	// CityLargestArea returns the city whose shape has the largest area.
+	CityLargestArea
+)
+
+// populationKeys are the GeoJSON properties read for CityLargestPopulation.
+var populationKeys = []string{"max_pop_al", "pop_max", "POP_MAX", "POP_EST"}
+
+// WithCityPreference sets how to pick between overlapping city shapes.
+func WithCityPreference(pref CityPreference) Option {
+	return func(o *options) {
+		o.cityPreference = pref
+	}
+}
+
+// preferredCity returns the name of the city from the given shapes which best
+// matches the CityPreference, ties go to the first shape.
+func (r *Rgeo) preferredCity(shapes []s2.Shape) string {
+	var (
+		city string
+		best float64
+	)
+
+	for _, shape := range shapes {
+		name := r.locs[shape].City
+		if name == "" {
+			continue
+		}
+
+		var v float64
+		switch r.opts.cityPreference {
+		case CityLargestPopulation:
+			v = r.cityPops[shape]
+		case CityLargestArea:
+			if p, ok := shape.(*s2.Polygon); ok {
+				v = p.Area()
+			}
+		}
+
+		if city == "" || v > best {
+			city, best = name, v
+		}
+	}
+
+	return city
+}
+
+// cityCentre is the centroid of a city shape, for NearestCity.
+type cityCentre struct {
+	name string
+	p    s2.Point
+}
+
+// NearestCity returns the name of the city whose centre is nearest to loc, and
+// the distance to it in meters, as long as it's within maxMeters. This is
+// often more useful than the City from ReverseGeocode for points just outside
+// of a city's urban area, or for finding which city a point in the suburbs
+// belongs to when the urban areas are drawn together. If there's no city
+// within maxMeters it returns ErrLocationNotFound.
+//
+// It needs a dataset with city shapes to be loaded, i.e. Cities10, otherwise
+// it always returns ErrLocationNotFound. The centre of each city is the
+// centroid of its shape, which is worked out the first time NearestCity is
+// called, so it's the middle of the urban area rather than the historic
+// centre, and a city made of several shapes has a centre for each of them.
+// Distances are measured on a sphere, as in NearestLocation.
+func (r *Rgeo) NearestCity(loc orb.Point, maxMeters float64) (string, float64, error) {
+	if maxMeters < 0 || math.IsNaN(maxMeters) {
+		return "", 0, fmt.Errorf("invalid distance: %v", maxMeters)
+	}
+
+	p := pointFromCoord(loc)
+
+	var (
+		city string
+		best = chordAngleFromMeters(maxMeters)
+	)
+
+	for _, c := range r.cityCentres() {
+		if d := s2.ChordAngleBetweenPoints(p, c.p); d < best {
+			city, best = c.name, d
+		}
+	}
+
+	if city == "" {
+		return "", 0, ErrLocationNotFound
+	}
+
+	return city, metersFromChordAngle(best), nil
+}
+
+// cityCentres returns the centre of every city shape, in the order they were
+// loaded, working them out the first time it's called.
+func (r *Rgeo) cityCentres() []cityCentre {
+	r.citiesOnce.Do(func() {
+		for i := int32(0); i < int32(r.index.Len()); i++ {
+			shape, ok := r.index.Shape(i).(*s2.Polygon)
+			if !ok || r.locs[shape].City == "" {
+				continue
+			}
+
+			r.cities = append(r.cities, cityCentre{
+				name: r.locs[shape].City,
+				p:    s2.Point{Vector: shape.Centroid().Normalize()},
+			})
+		}
+	})
+
+	return r.cities
+}
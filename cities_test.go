@@ -0,0 +1,131 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/paulmach/orb"
+)
+
+// citiesGeo has a small populous city overlapping a bigger, emptier one.
+const citiesGeo = `{
+	"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"name_conve":"Sprawl","max_pop_al":1000},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,0],[2,0],[2,2],[0,2],[0,0]]]}},
+			{"type":"Feature",
+			"properties":{"name_conve":"Dense","max_pop_al":50000},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[1,1],[1.5,1],[1.5,1.5],[1,1.5],[1,1]]]}}
+		]
+	}`
+
+func TestWithCityPreference(t *testing.T) {
+	dataset := func() []byte { return compressData(t, citiesGeo) }
+
+	tests := []struct {
+		name     string
+		pref     CityPreference
+		expected string
+	}{
+		{"first", CityFirst, "Sprawl"},
+		{"population", CityLargestPopulation, "Dense"},
+		{"area", CityLargestArea, "Sprawl"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			r, err := NewWithOptions([]func() []byte{dataset}, WithCityPreference(test.pref))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			loc, err := r.ReverseGeocode(orb.Point{1.2, 1.2})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if loc.City != test.expected {
+				t.Errorf("expected: %s, got: %s", test.expected, loc.City)
+			}
+
+			// Outside the overlap there is only one choice.
+			loc, err = r.ReverseGeocode(orb.Point{0.5, 0.5})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if loc.City != "Sprawl" {
+				t.Errorf("expected: Sprawl, got: %s", loc.City)
+			}
+		})
+	}
+}
+
+func TestNearestCity(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, citiesGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The centres are (1, 1) for Sprawl and (1.25, 1.25) for Dense.
+	tests := []struct {
+		name      string
+		in        orb.Point
+		maxMeters float64
+		expected  string
+		dist      float64
+		err       error
+	}{
+		{"InsideBoth", orb.Point{1.3, 1.3}, 10000, "Dense", 7860, nil},
+		{"InsideSprawl", orb.Point{0.2, 0.2}, 150000, "Sprawl", 125800, nil},
+		{"Outside", orb.Point{3, 1}, 250000, "Dense", 196500, nil},
+		{"TooFar", orb.Point{5, 5}, 100000, "", 0, ErrLocationNotFound},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			city, dist, err := r.NearestCity(test.in, test.maxMeters)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error: %v\n got: %v\n", test.err, err)
+			}
+			if city != test.expected {
+				t.Errorf("expected: %s, got: %s", test.expected, city)
+			}
+			if math.Abs(dist-test.dist) > test.dist*0.01 {
+				t.Errorf("expected distance: %f, got: %f", test.dist, dist)
+			}
+		})
+	}
+
+	if _, _, err := r.NearestCity(orb.Point{0, 0}, -1); err == nil {
+		t.Error("expected error for negative distance")
+	}
+
+	// Without any city shapes there's nothing to find.
+	countries, err := New(func() []byte { return compressData(t, squareGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := countries.NearestCity(orb.Point{0.5, 52.5}, 1e6); !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("expected error: %v\n got: %v\n", ErrLocationNotFound, err)
+	}
+}
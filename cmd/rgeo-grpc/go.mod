@@ -0,0 +1,24 @@
+module github.com/sams96/rgeo/cmd/rgeo-grpc
+
+go 1.23.0
+
+require (
+	github.com/paulmach/orb v0.11.1
+	github.com/sams96/rgeo v1.2.0
+	github.com/sams96/rgeo/locationpb v0.0.0
+	google.golang.org/grpc v1.75.0
+	google.golang.org/protobuf v1.36.9
+)
+
+require (
+	github.com/golang/geo v0.0.0-20230421003525-6adc56603217 // indirect
+	go.mongodb.org/mongo-driver v1.11.4 // indirect
+	golang.org/x/net v0.41.0 // indirect
+	golang.org/x/sys v0.33.0 // indirect
+	golang.org/x/text v0.26.0 // indirect
+	google.golang.org/genproto/googleapis/rpc v0.0.0-20250707201910-8d1bb00bc6a7 // indirect
+)
+
+replace github.com/sams96/rgeo => ../..
+
+replace github.com/sams96/rgeo/locationpb => ../../locationpb
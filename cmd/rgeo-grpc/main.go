@@ -0,0 +1,101 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+/*
+Command rgeo-grpc serves rgeo over gRPC, so it can be used from languages other
+than Go. The service is defined in rgeopb/rgeo.proto, generate clients for
+other languages from that. The Locations it returns are the messages from
+locationpb/location.proto at the root of the repository, so that has to be
+on the import path as well.
+
+It is a separate module so that users of the rgeo library don't have to depend
+on gRPC.
+
+Usage
+
+	go run . -addr :50051 -datasets Provinces10,Cities10
+
+The datasets are the ones included in rgeo: Countries110, Countries10,
+Provinces10, US_Counties10 and Cities10.
+*/
+package main
+
+//go:generate protoc -I. -I../.. --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative rgeopb/rgeo.proto
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"net"
+	"strings"
+
+	"github.com/sams96/rgeo"
+	"github.com/sams96/rgeo/cmd/rgeo-grpc/rgeopb"
+	"google.golang.org/grpc"
+)
+
+var datasets = map[string]func() []byte{
+	"Countries110":  rgeo.Countries110,
+	"Countries10":   rgeo.Countries10,
+	"Provinces10":   rgeo.Provinces10,
+	"US_Counties10": rgeo.US_Counties10,
+	"Cities10":      rgeo.Cities10,
+}
+
+func main() {
+	addr := flag.String("addr", ":50051", "Address to listen on")
+	names := flag.String("datasets", "Countries110", "Comma separated datasets to load")
+
+	flag.Parse()
+
+	ds, err := parseDatasets(*names)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	r, err := rgeo.New(ds...)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	lis, err := net.Listen("tcp", *addr)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	s := grpc.NewServer()
+	rgeopb.RegisterRgeoServer(s, &server{r: r})
+
+	log.Printf("listening on %s", lis.Addr())
+	if err := s.Serve(lis); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parseDatasets returns the dataset functions for a comma separated list of
+// dataset names.
+func parseDatasets(names string) ([]func() []byte, error) {
+	var ret []func() []byte
+	for _, name := range strings.Split(names, ",") {
+		ds, ok := datasets[strings.TrimSpace(name)]
+		if !ok {
+			return nil, fmt.Errorf("unknown dataset %q", name)
+		}
+
+		ret = append(ret, ds)
+	}
+
+	return ret, nil
+}
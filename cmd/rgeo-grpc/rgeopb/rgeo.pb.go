@@ -0,0 +1,213 @@
+// Copyright 2020 Sam Smith
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License.  You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: rgeopb/rgeo.proto
+
+package rgeopb
+
+import (
+	locationpb "github.com/sams96/rgeo/locationpb"
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type ReverseGeocodeRequest struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Coordinates in degrees (WGS84).
+	Lat           float64 `protobuf:"fixed64,1,opt,name=lat,proto3" json:"lat,omitempty"`
+	Lon           float64 `protobuf:"fixed64,2,opt,name=lon,proto3" json:"lon,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReverseGeocodeRequest) Reset() {
+	*x = ReverseGeocodeRequest{}
+	mi := &file_rgeopb_rgeo_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReverseGeocodeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReverseGeocodeRequest) ProtoMessage() {}
+
+func (x *ReverseGeocodeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_rgeopb_rgeo_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReverseGeocodeRequest.ProtoReflect.Descriptor instead.
+func (*ReverseGeocodeRequest) Descriptor() ([]byte, []int) {
+	return file_rgeopb_rgeo_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *ReverseGeocodeRequest) GetLat() float64 {
+	if x != nil {
+		return x.Lat
+	}
+	return 0
+}
+
+func (x *ReverseGeocodeRequest) GetLon() float64 {
+	if x != nil {
+		return x.Lon
+	}
+	return 0
+}
+
+type ReverseGeocodeResponse struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	Location *locationpb.Location   `protobuf:"bytes,1,opt,name=location,proto3" json:"location,omitempty"`
+	// False if no location contains the coordinate, location is empty then.
+	Found         bool `protobuf:"varint,2,opt,name=found,proto3" json:"found,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReverseGeocodeResponse) Reset() {
+	*x = ReverseGeocodeResponse{}
+	mi := &file_rgeopb_rgeo_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReverseGeocodeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReverseGeocodeResponse) ProtoMessage() {}
+
+func (x *ReverseGeocodeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_rgeopb_rgeo_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReverseGeocodeResponse.ProtoReflect.Descriptor instead.
+func (*ReverseGeocodeResponse) Descriptor() ([]byte, []int) {
+	return file_rgeopb_rgeo_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *ReverseGeocodeResponse) GetLocation() *locationpb.Location {
+	if x != nil {
+		return x.Location
+	}
+	return nil
+}
+
+func (x *ReverseGeocodeResponse) GetFound() bool {
+	if x != nil {
+		return x.Found
+	}
+	return false
+}
+
+var File_rgeopb_rgeo_proto protoreflect.FileDescriptor
+
+const file_rgeopb_rgeo_proto_rawDesc = "" +
+	"\n" +
+	"\x11rgeopb/rgeo.proto\x12\argeo.v1\x1a\x19locationpb/location.proto\";\n" +
+	"\x15ReverseGeocodeRequest\x12\x10\n" +
+	"\x03lat\x18\x01 \x01(\x01R\x03lat\x12\x10\n" +
+	"\x03lon\x18\x02 \x01(\x01R\x03lon\"f\n" +
+	"\x16ReverseGeocodeResponse\x126\n" +
+	"\blocation\x18\x01 \x01(\v2\x1a.rgeo.location.v1.LocationR\blocation\x12\x14\n" +
+	"\x05found\x18\x02 \x01(\bR\x05found2\xb1\x01\n" +
+	"\x04Rgeo\x12L\n" +
+	"\x0eReverseGeocode\x12\x1e.rgeo.v1.ReverseGeocodeRequest\x1a\x1a.rgeo.location.v1.Location\x12[\n" +
+	"\x14ReverseGeocodeStream\x12\x1e.rgeo.v1.ReverseGeocodeRequest\x1a\x1f.rgeo.v1.ReverseGeocodeResponse(\x010\x01B-Z+github.com/sams96/rgeo/cmd/rgeo-grpc/rgeopbb\x06proto3"
+
+var (
+	file_rgeopb_rgeo_proto_rawDescOnce sync.Once
+	file_rgeopb_rgeo_proto_rawDescData []byte
+)
+
+func file_rgeopb_rgeo_proto_rawDescGZIP() []byte {
+	file_rgeopb_rgeo_proto_rawDescOnce.Do(func() {
+		file_rgeopb_rgeo_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_rgeopb_rgeo_proto_rawDesc), len(file_rgeopb_rgeo_proto_rawDesc)))
+	})
+	return file_rgeopb_rgeo_proto_rawDescData
+}
+
+var file_rgeopb_rgeo_proto_msgTypes = make([]protoimpl.MessageInfo, 2)
+var file_rgeopb_rgeo_proto_goTypes = []any{
+	(*ReverseGeocodeRequest)(nil),  // 0: rgeo.v1.ReverseGeocodeRequest
+	(*ReverseGeocodeResponse)(nil), // 1: rgeo.v1.ReverseGeocodeResponse
+	(*locationpb.Location)(nil),    // 2: rgeo.location.v1.Location
+}
+var file_rgeopb_rgeo_proto_depIdxs = []int32{
+	2, // 0: rgeo.v1.ReverseGeocodeResponse.location:type_name -> rgeo.location.v1.Location
+	0, // 1: rgeo.v1.Rgeo.ReverseGeocode:input_type -> rgeo.v1.ReverseGeocodeRequest
+	0, // 2: rgeo.v1.Rgeo.ReverseGeocodeStream:input_type -> rgeo.v1.ReverseGeocodeRequest
+	2, // 3: rgeo.v1.Rgeo.ReverseGeocode:output_type -> rgeo.location.v1.Location
+	1, // 4: rgeo.v1.Rgeo.ReverseGeocodeStream:output_type -> rgeo.v1.ReverseGeocodeResponse
+	3, // [3:5] is the sub-list for method output_type
+	1, // [1:3] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_rgeopb_rgeo_proto_init() }
+func file_rgeopb_rgeo_proto_init() {
+	if File_rgeopb_rgeo_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_rgeopb_rgeo_proto_rawDesc), len(file_rgeopb_rgeo_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   2,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_rgeopb_rgeo_proto_goTypes,
+		DependencyIndexes: file_rgeopb_rgeo_proto_depIdxs,
+		MessageInfos:      file_rgeopb_rgeo_proto_msgTypes,
+	}.Build()
+	File_rgeopb_rgeo_proto = out.File
+	file_rgeopb_rgeo_proto_goTypes = nil
+	file_rgeopb_rgeo_proto_depIdxs = nil
+}
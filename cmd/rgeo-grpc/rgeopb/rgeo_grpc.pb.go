@@ -0,0 +1,181 @@
+// Copyright 2020 Sam Smith
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License.  You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.5.1
+// - protoc             (unknown)
+// source: rgeopb/rgeo.proto
+
+package rgeopb
+
+import (
+	context "context"
+	locationpb "github.com/sams96/rgeo/locationpb"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.64.0 or later.
+const _ = grpc.SupportPackageIsVersion9
+
+const (
+	Rgeo_ReverseGeocode_FullMethodName       = "/rgeo.v1.Rgeo/ReverseGeocode"
+	Rgeo_ReverseGeocodeStream_FullMethodName = "/rgeo.v1.Rgeo/ReverseGeocodeStream"
+)
+
+// RgeoClient is the client API for Rgeo service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// Rgeo reverse geocodes coordinates using the datasets loaded by the server.
+type RgeoClient interface {
+	// ReverseGeocode returns the location containing the given coordinate, or
+	// a NOT_FOUND error if there isn't one.
+	ReverseGeocode(ctx context.Context, in *ReverseGeocodeRequest, opts ...grpc.CallOption) (*locationpb.Location, error)
+	// ReverseGeocodeStream reverse geocodes a stream of coordinates, returning
+	// one response for each request in the same order.
+	ReverseGeocodeStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ReverseGeocodeRequest, ReverseGeocodeResponse], error)
+}
+
+type rgeoClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewRgeoClient(cc grpc.ClientConnInterface) RgeoClient {
+	return &rgeoClient{cc}
+}
+
+func (c *rgeoClient) ReverseGeocode(ctx context.Context, in *ReverseGeocodeRequest, opts ...grpc.CallOption) (*locationpb.Location, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(locationpb.Location)
+	err := c.cc.Invoke(ctx, Rgeo_ReverseGeocode_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *rgeoClient) ReverseGeocodeStream(ctx context.Context, opts ...grpc.CallOption) (grpc.BidiStreamingClient[ReverseGeocodeRequest, ReverseGeocodeResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &Rgeo_ServiceDesc.Streams[0], Rgeo_ReverseGeocodeStream_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[ReverseGeocodeRequest, ReverseGeocodeResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Rgeo_ReverseGeocodeStreamClient = grpc.BidiStreamingClient[ReverseGeocodeRequest, ReverseGeocodeResponse]
+
+// RgeoServer is the server API for Rgeo service.
+// All implementations must embed UnimplementedRgeoServer
+// for forward compatibility.
+//
+// Rgeo reverse geocodes coordinates using the datasets loaded by the server.
+type RgeoServer interface {
+	// ReverseGeocode returns the location containing the given coordinate, or
+	// a NOT_FOUND error if there isn't one.
+	ReverseGeocode(context.Context, *ReverseGeocodeRequest) (*locationpb.Location, error)
+	// ReverseGeocodeStream reverse geocodes a stream of coordinates, returning
+	// one response for each request in the same order.
+	ReverseGeocodeStream(grpc.BidiStreamingServer[ReverseGeocodeRequest, ReverseGeocodeResponse]) error
+	mustEmbedUnimplementedRgeoServer()
+}
+
+// UnimplementedRgeoServer must be embedded to have
+// forward compatible implementations.
+//
+// NOTE: this should be embedded by value instead of pointer to avoid a nil
+// pointer dereference when methods are called.
+type UnimplementedRgeoServer struct{}
+
+func (UnimplementedRgeoServer) ReverseGeocode(context.Context, *ReverseGeocodeRequest) (*locationpb.Location, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReverseGeocode not implemented")
+}
+func (UnimplementedRgeoServer) ReverseGeocodeStream(grpc.BidiStreamingServer[ReverseGeocodeRequest, ReverseGeocodeResponse]) error {
+	return status.Errorf(codes.Unimplemented, "method ReverseGeocodeStream not implemented")
+}
+func (UnimplementedRgeoServer) mustEmbedUnimplementedRgeoServer() {}
+func (UnimplementedRgeoServer) testEmbeddedByValue()              {}
+
+// UnsafeRgeoServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to RgeoServer will
+// result in compilation errors.
+type UnsafeRgeoServer interface {
+	mustEmbedUnimplementedRgeoServer()
+}
+
+func RegisterRgeoServer(s grpc.ServiceRegistrar, srv RgeoServer) {
+	// If the following call pancis, it indicates UnimplementedRgeoServer was
+	// embedded by pointer and is nil.  This will cause panics if an
+	// unimplemented method is ever invoked, so we test this at initialization
+	// time to prevent it from happening at runtime later due to I/O.
+	if t, ok := srv.(interface{ testEmbeddedByValue() }); ok {
+		t.testEmbeddedByValue()
+	}
+	s.RegisterService(&Rgeo_ServiceDesc, srv)
+}
+
+func _Rgeo_ReverseGeocode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReverseGeocodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(RgeoServer).ReverseGeocode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Rgeo_ReverseGeocode_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(RgeoServer).ReverseGeocode(ctx, req.(*ReverseGeocodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Rgeo_ReverseGeocodeStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(RgeoServer).ReverseGeocodeStream(&grpc.GenericServerStream[ReverseGeocodeRequest, ReverseGeocodeResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type Rgeo_ReverseGeocodeStreamServer = grpc.BidiStreamingServer[ReverseGeocodeRequest, ReverseGeocodeResponse]
+
+// Rgeo_ServiceDesc is the grpc.ServiceDesc for Rgeo service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var Rgeo_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "rgeo.v1.Rgeo",
+	HandlerType: (*RgeoServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "ReverseGeocode",
+			Handler:    _Rgeo_ReverseGeocode_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "ReverseGeocodeStream",
+			Handler:       _Rgeo_ReverseGeocodeStream_Handler,
+			ServerStreams: true,
+			ClientStreams: true,
+		},
+	},
+	Metadata: "rgeopb/rgeo.proto",
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+
+	"github.com/paulmach/orb"
+	"github.com/sams96/rgeo"
+	"github.com/sams96/rgeo/cmd/rgeo-grpc/rgeopb"
+	"github.com/sams96/rgeo/locationpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// server implements rgeopb.RgeoServer on top of an *rgeo.Rgeo.
+type server struct {
+	rgeopb.UnimplementedRgeoServer
+
+	r *rgeo.Rgeo
+}
+
+// ReverseGeocode implements rgeopb.RgeoServer.
+func (s *server) ReverseGeocode(_ context.Context, req *rgeopb.ReverseGeocodeRequest) (*locationpb.Location, error) {
+	loc, err := s.r.ReverseGeocode(orb.Point{req.GetLon(), req.GetLat()})
+	if errors.Is(err, rgeo.ErrLocationNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return locationpb.ToProto(loc), nil
+}
+
+// ReverseGeocodeStream implements rgeopb.RgeoServer.
+func (s *server) ReverseGeocodeStream(stream rgeopb.Rgeo_ReverseGeocodeStreamServer) error {
+	for {
+		req, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		resp := new(rgeopb.ReverseGeocodeResponse)
+		loc, err := s.r.ReverseGeocode(orb.Point{req.GetLon(), req.GetLat()})
+		switch {
+		case err == nil:
+			resp.Location = locationpb.ToProto(loc)
+			resp.Found = true
+		case !errors.Is(err, rgeo.ErrLocationNotFound):
+			return status.Error(codes.Internal, err.Error())
+		}
+
+		if err := stream.Send(resp); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,127 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/geojson"
+	"github.com/sams96/rgeo"
+	"github.com/sams96/rgeo/cmd/rgeo-grpc/rgeopb"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+// newTestClient starts a server with a single 1x1 degree square country and
+// returns a client connected to it.
+func newTestClient(t *testing.T) rgeopb.RgeoClient {
+	fc := geojson.NewFeatureCollection()
+	fc.Append(geojson.NewFeature(orb.Polygon{{{0, 52}, {1, 52}, {1, 53}, {0, 53}, {0, 52}}}))
+	fc.Features[0].Properties["ISO_A3"] = "TST"
+	fc.Features[0].Properties["NE_ID"] = "1"
+
+	r, err := rgeo.NewFromFeatureCollection(fc, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	lis := bufconn.Listen(1 << 20)
+	s := grpc.NewServer()
+	rgeopb.RegisterRgeoServer(s, &server{r: r})
+	go func() { _ = s.Serve(lis) }()
+	t.Cleanup(s.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(context.Context, string) (net.Conn, error) { return lis.Dial() }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { conn.Close() })
+
+	return rgeopb.NewRgeoClient(conn)
+}
+
+func TestReverseGeocode(t *testing.T) {
+	c := newTestClient(t)
+
+	loc, err := c.ReverseGeocode(context.Background(), &rgeopb.ReverseGeocodeRequest{Lat: 52.5, Lon: 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loc.GetCountryCode_3() != "TST" {
+		t.Errorf("expected: TST, got: %s", loc.GetCountryCode_3())
+	}
+	if loc.GetFeatureId() != "1" {
+		t.Errorf("expected feature id: 1, got: %s", loc.GetFeatureId())
+	}
+
+	_, err = c.ReverseGeocode(context.Background(), &rgeopb.ReverseGeocodeRequest{Lat: 0, Lon: 0})
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got: %s", err)
+	}
+}
+
+func TestReverseGeocodeStream(t *testing.T) {
+	c := newTestClient(t)
+
+	stream, err := c.ReverseGeocodeStream(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	reqs := []*rgeopb.ReverseGeocodeRequest{{Lat: 52.5, Lon: 0.5}, {Lat: 0, Lon: 0}}
+	expected := []bool{true, false}
+
+	for i, req := range reqs {
+		if err := stream.Send(req); err != nil {
+			t.Fatal(err)
+		}
+
+		resp, err := stream.Recv()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if resp.GetFound() != expected[i] {
+			t.Errorf("request %d: expected found: %t, got: %t", i, expected[i], resp.GetFound())
+		}
+	}
+
+	if err := stream.CloseSend(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestParseDatasets(t *testing.T) {
+	ds, err := parseDatasets("Provinces10, Cities10")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ds) != 2 {
+		t.Errorf("expected 2 datasets, got %d", len(ds))
+	}
+
+	if _, err := parseDatasets("Atlantis"); err == nil {
+		t.Error("expected error for unknown dataset")
+	}
+}
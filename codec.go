@@ -0,0 +1,108 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"sync"
+)
+
+// Decompressor returns a reader for the decompressed contents of r, for use
+// with RegisterDecompressor. The reader is closed once the dataset has been
+// read.
+type Decompressor func(r io.Reader) (io.ReadCloser, error)
+
+// gzipMagic is the header every gzip file starts with.
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// decompressor is a registered Decompressor and the header it's used for.
+type decompressor struct {
+	magic []byte
+	fn    Decompressor
+}
+
+var (
+	decompressorsLock sync.RWMutex
+
+	// decompressors are checked in order. A header that's registered again
+	// keeps its place with the new Decompressor, and new headers are added to
+	// the front, so they're checked before any shorter header they start with.
+	decompressors = []decompressor{{
+		magic: gzipMagic,
+		fn:    func(r io.Reader) (io.ReadCloser, error) { return gzip.NewReader(r) },
+	}}
+)
+
+// RegisterDecompressor makes New, NewWithOptions and NewFromFS accept datasets
+// compressed with another codec, picked by the magic bytes at the start of the
+// data. Registering a header that's already registered replaces its
+// Decompressor, and gzip is always registered, so the included datasets and
+// anything else made with datagen's defaults keep working.
+//
+// Decompressing is only a small part of loading a dataset though, most of the
+// time goes on decoding the GeoJSON. BenchmarkDecodeDataset has Countries10
+// taking only around 15% longer gzipped than uncompressed, so that's the most
+// a faster codec can save.
+//
+// rgeo doesn't depend on any other compression libraries itself. For example,
+// to load datasets made with datagen -codec zstd, using
+// github.com/klauspost/compress/zstd:
+//
+//	rgeo.RegisterDecompressor([]byte{0x28, 0xb5, 0x2f, 0xfd}, func(r io.Reader) (io.ReadCloser, error) {
+//		d, err := zstd.NewReader(r)
+//		if err != nil {
+//			return nil, err
+//		}
+//		return d.IOReadCloser(), nil
+//	})
+//
+// It's safe to call concurrently, but should normally be called from init. It
+// panics if d is nil.
+func RegisterDecompressor(magic []byte, d Decompressor) {
+	if d == nil {
+		panic("rgeo: RegisterDecompressor with nil Decompressor")
+	}
+
+	decompressorsLock.Lock()
+	defer decompressorsLock.Unlock()
+
+	m := append([]byte{}, magic...)
+	for i, dec := range decompressors {
+		if bytes.Equal(dec.magic, m) {
+			decompressors[i].fn = d
+			return
+		}
+	}
+
+	decompressors = append([]decompressor{{magic: m, fn: d}}, decompressors...)
+}
+
+// decompressorFor returns the Decompressor for the header b starts with, ok is
+// false if there isn't one registered.
+func decompressorFor(b []byte) (d Decompressor, ok bool) {
+	decompressorsLock.RLock()
+	defer decompressorsLock.RUnlock()
+
+	for _, dec := range decompressors {
+		if len(dec.magic) > 0 && bytes.HasPrefix(b, dec.magic) {
+			return dec.fn, true
+		}
+	}
+
+	return nil, false
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+	"testing/fstest"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+// testMagic is the header for a fake codec, which is just the magic followed
+// by the uncompressed data.
+var testMagic = []byte("RGEOTEST")
+
+func init() {
+	RegisterDecompressor(testMagic, func(r io.Reader) (io.ReadCloser, error) {
+		if _, err := io.CopyN(io.Discard, r, int64(len(testMagic))); err != nil {
+			return nil, err
+		}
+		return io.NopCloser(r), nil
+	})
+}
+
+func TestRegisterDecompressor(t *testing.T) {
+	data := append(append([]byte{}, testMagic...), squareGeo...)
+
+	r, err := New(func() []byte { return data })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loc, err := r.ReverseGeocode(orb.Point{0.5, 52.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(Location{CountryCode3: "TST"}, loc); diff != nil {
+		t.Error(diff)
+	}
+
+	r, err = NewFromFS(fstest.MapFS{"square.test": {Data: data}}, "square.test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.ReverseGeocode(orb.Point{0.5, 52.5}); err != nil {
+		t.Error(err)
+	}
+
+	// Gzip still works alongside it, and unknown headers are still errors.
+	if _, err := New(func() []byte { return compressData(t, squareGeo) }); err != nil {
+		t.Error(err)
+	}
+	if _, err := New(func() []byte { return []byte(squareGeo) }); err == nil {
+		t.Error("expected error for uncompressed data")
+	}
+}
+
+func TestRegisterDecompressor_Nil(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("expected panic for nil Decompressor")
+		}
+	}()
+
+	RegisterDecompressor([]byte("nil"), nil)
+}
+
+// BenchmarkDecodeDataset compares decoding Countries10 gzipped, as it's
+// included, with decoding it uncompressed, which is the lower bound for any
+// other codec registered with RegisterDecompressor.
+func BenchmarkDecodeDataset(b *testing.B) {
+	zr, err := gzip.NewReader(bytes.NewReader(Countries10()))
+	if err != nil {
+		b.Fatal(err)
+	}
+	raw, err := io.ReadAll(zr)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	b.Run("gzip", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := decodeDataset(Countries10(), true, "Countries10"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+
+	b.Run("none", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			if _, err := decodeDataset(raw, false, "Countries10"); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
@@ -0,0 +1,39 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import "github.com/paulmach/orb"
+
+// AnyContained returns the index of the first of the points which is inside
+// any of the loaded shapes, i.e. the first one ReverseGeocode would find a
+// Location for, and true. If none of them are it returns -1 and false.
+//
+// The points are checked in order and it stops at the first hit, without
+// working out any Locations, so it's much cheaper than geocoding all of them
+// when you only need to know if any are inside, e.g. loading just one country
+// with WithFeatureFilter to check whether a track ever entered it.
+func (r *Rgeo) AnyContained(points []orb.Point) (int, bool) {
+	containsPointQueryLock.Lock()
+	defer containsPointQueryLock.Unlock()
+
+	for i, p := range points {
+		if r.query.Contains(pointFromCoord(p)) {
+			return i, true
+		}
+	}
+
+	return -1, false
+}
@@ -0,0 +1,51 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"testing"
+
+	"github.com/paulmach/orb"
+)
+
+func TestAnyContained(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, twoSquaresGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		points []orb.Point
+		index  int
+		found  bool
+	}{
+		{"First", []orb.Point{{0.5, 0.5}, {2.5, 0.5}}, 0, true},
+		{"Later", []orb.Point{{1.5, 0.5}, {5, 5}, {2.5, 0.5}, {0.5, 0.5}}, 2, true},
+		{"None", []orb.Point{{1.5, 0.5}, {5, 5}}, -1, false},
+		{"Empty", nil, -1, false},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			i, ok := r.AnyContained(test.points)
+			if i != test.index || ok != test.found {
+				t.Errorf("expected: %d, %v\n got: %d, %v\n", test.index, test.found, i, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,229 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+// Continent is one of the values of Location.Continent used by Natural Earth.
+type Continent string
+
+// The Natural Earth continents, from the CONTINENT property.
+const (
+	ContinentAfrica       Continent = "Africa"
+	ContinentAntarctica   Continent = "Antarctica"
+	ContinentAsia         Continent = "Asia"
+	ContinentEurope       Continent = "Europe"
+	ContinentNorthAmerica Continent = "North America"
+	ContinentOceania      Continent = "Oceania"
+	ContinentSevenSeas    Continent = "Seven seas (open ocean)"
+	ContinentSouthAmerica Continent = "South America"
+)
+
+var continents = []Continent{
+	ContinentAfrica,
+	ContinentAntarctica,
+	ContinentAsia,
+	ContinentEurope,
+	ContinentNorthAmerica,
+	ContinentOceania,
+	ContinentSevenSeas,
+	ContinentSouthAmerica,
+}
+
+// Region is one of the values of Location.Region used by Natural Earth.
+type Region string
+
+// The UN regions used by Natural Earth, from the REGION_UN property.
+const (
+	RegionAfrica     Region = "Africa"
+	RegionAmericas   Region = "Americas"
+	RegionAntarctica Region = "Antarctica"
+	RegionAsia       Region = "Asia"
+	RegionEurope     Region = "Europe"
+	RegionOceania    Region = "Oceania"
+)
+
+var regions = []Region{
+	RegionAfrica,
+	RegionAmericas,
+	RegionAntarctica,
+	RegionAsia,
+	RegionEurope,
+	RegionOceania,
+}
+
+// SubRegion is one of the values of Location.SubRegion used by Natural Earth.
+type SubRegion string
+
+// The UN sub-regions used by Natural Earth, from the SUBREGION property.
+const (
+	SubRegionAntarctica             SubRegion = "Antarctica"
+	SubRegionAustraliaAndNewZealand SubRegion = "Australia and New Zealand"
+	SubRegionCaribbean              SubRegion = "Caribbean"
+	SubRegionCentralAmerica         SubRegion = "Central America"
+	SubRegionCentralAsia            SubRegion = "Central Asia"
+	SubRegionEasternAfrica          SubRegion = "Eastern Africa"
+	SubRegionEasternAsia            SubRegion = "Eastern Asia"
+	SubRegionEasternEurope          SubRegion = "Eastern Europe"
+	SubRegionMelanesia              SubRegion = "Melanesia"
+	SubRegionMicronesia             SubRegion = "Micronesia"
+	SubRegionMiddleAfrica           SubRegion = "Middle Africa"
+	SubRegionNorthernAfrica         SubRegion = "Northern Africa"
+	SubRegionNorthernAmerica        SubRegion = "Northern America"
+	SubRegionNorthernEurope         SubRegion = "Northern Europe"
+	SubRegionPolynesia              SubRegion = "Polynesia"
+	SubRegionSevenSeas              SubRegion = "Seven seas (open ocean)"
+	SubRegionSouthAmerica           SubRegion = "South America"
+	SubRegionSouthEasternAsia       SubRegion = "South-Eastern Asia"
+	SubRegionSouthernAfrica         SubRegion = "Southern Africa"
+	SubRegionSouthernAsia           SubRegion = "Southern Asia"
+	SubRegionSouthernEurope         SubRegion = "Southern Europe"
+	SubRegionWesternAfrica          SubRegion = "Western Africa"
+	SubRegionWesternAsia            SubRegion = "Western Asia"
+	SubRegionWesternEurope          SubRegion = "Western Europe"
+)
+
+var subRegions = []SubRegion{
+	SubRegionAntarctica,
+	SubRegionAustraliaAndNewZealand,
+	SubRegionCaribbean,
+	SubRegionCentralAmerica,
+	SubRegionCentralAsia,
+	SubRegionEasternAfrica,
+	SubRegionEasternAsia,
+	SubRegionEasternEurope,
+	SubRegionMelanesia,
+	SubRegionMicronesia,
+	SubRegionMiddleAfrica,
+	SubRegionNorthernAfrica,
+	SubRegionNorthernAmerica,
+	SubRegionNorthernEurope,
+	SubRegionPolynesia,
+	SubRegionSevenSeas,
+	SubRegionSouthAmerica,
+	SubRegionSouthEasternAsia,
+	SubRegionSouthernAfrica,
+	SubRegionSouthernAsia,
+	SubRegionSouthernEurope,
+	SubRegionWesternAfrica,
+	SubRegionWesternAsia,
+	SubRegionWesternEurope,
+}
+
+// ParseContinent returns s as a Continent, ok is false if s isn't one of the
+// known Natural Earth continents.
+func ParseContinent(s string) (c Continent, ok bool) {
+	for _, c := range continents {
+		if string(c) == s {
+			return c, true
+		}
+	}
+
+	return "", false
+}
+
+// ParseRegion returns s as a Region, ok is false if s isn't one of the known
+// Natural Earth regions.
+func ParseRegion(s string) (r Region, ok bool) {
+	for _, r := range regions {
+		if string(r) == s {
+			return r, true
+		}
+	}
+
+	return "", false
+}
+
+// ParseSubRegion returns s as a SubRegion, ok is false if s isn't one of the
+// known Natural Earth sub-regions.
+func ParseSubRegion(s string) (r SubRegion, ok bool) {
+	for _, r := range subRegions {
+		if string(r) == s {
+			return r, true
+		}
+	}
+
+	return "", false
+}
+
+// ContinentValue returns the Continent of the Location, ok is false if the
+// Continent field is empty or not a known value.
+func (l Location) ContinentValue() (Continent, bool) {
+	return ParseContinent(l.Continent)
+}
+
+// RegionValue returns the Region of the Location, ok is false if the Region
+// field is empty or not a known value.
+func (l Location) RegionValue() (Region, bool) {
+	return ParseRegion(l.Region)
+}
+
+// SubRegionValue returns the SubRegion of the Location, ok is false if the
+// SubRegion field is empty or not a known value.
+func (l Location) SubRegionValue() (SubRegion, bool) {
+	return ParseSubRegion(l.SubRegion)
+}
+
+// regionM49 are the UN M49 codes of the regions. Natural Earth doesn't have
+// them, only the names, and Antarctica isn't in a region in M49.
+var regionM49 = map[Region]string{
+	RegionAfrica:   "002",
+	RegionAmericas: "019",
+	RegionAsia:     "142",
+	RegionEurope:   "150",
+	RegionOceania:  "009",
+}
+
+// subRegionM49 are the UN M49 codes of the sub-regions. Those in Sub-Saharan
+// Africa and Latin America are intermediate regions in M49, which is as fine
+// as Natural Earth's sub-regions go there, and neither Antarctica nor the open
+// ocean are in one.
+var subRegionM49 = map[SubRegion]string{
+	SubRegionAustraliaAndNewZealand: "053",
+	SubRegionCaribbean:              "029",
+	SubRegionCentralAmerica:         "013",
+	SubRegionCentralAsia:            "143",
+	SubRegionEasternAfrica:          "014",
+	SubRegionEasternAsia:            "030",
+	SubRegionEasternEurope:          "151",
+	SubRegionMelanesia:              "054",
+	SubRegionMicronesia:             "057",
+	SubRegionMiddleAfrica:           "017",
+	SubRegionNorthernAfrica:         "015",
+	SubRegionNorthernAmerica:        "021",
+	SubRegionNorthernEurope:         "154",
+	SubRegionPolynesia:              "061",
+	SubRegionSouthAmerica:           "005",
+	SubRegionSouthEasternAsia:       "035",
+	SubRegionSouthernAfrica:         "018",
+	SubRegionSouthernAsia:           "034",
+	SubRegionSouthernEurope:         "039",
+	SubRegionWesternAfrica:          "011",
+	SubRegionWesternAsia:            "145",
+	SubRegionWesternEurope:          "155",
+}
+
+// M49 returns the three digit UN M49 code of the region, e.g. "150" for
+// RegionEurope, or "" for RegionAntarctica and unknown regions, which don't
+// have one.
+func (r Region) M49() string {
+	return regionM49[r]
+}
+
+// M49 returns the three digit UN M49 code of the sub-region, e.g. "154" for
+// SubRegionNorthernEurope, or "" for SubRegionAntarctica, SubRegionSevenSeas
+// and unknown sub-regions, which don't have one.
+func (r SubRegion) M49() string {
+	return subRegionM49[r]
+}
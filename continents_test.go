@@ -0,0 +1,104 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestParseContinent(t *testing.T) {
+	for _, c := range continents {
+		got, ok := ParseContinent(string(c))
+		if !ok || got != c {
+			t.Errorf("expected: %s, got: %s (%t)", c, got, ok)
+		}
+	}
+
+	if _, ok := ParseContinent("Atlantis"); ok {
+		t.Error("expected Atlantis not to be a continent")
+	}
+}
+
+func TestLocationContinentValue(t *testing.T) {
+	for _, test := range testdata {
+		if test.err != nil {
+			continue
+		}
+
+		if _, ok := test.expected.ContinentValue(); !ok {
+			t.Errorf("%s: unknown continent %q", test.name, test.expected.Continent)
+		}
+		if _, ok := test.expected.RegionValue(); !ok {
+			t.Errorf("%s: unknown region %q", test.name, test.expected.Region)
+		}
+		if _, ok := test.expected.SubRegionValue(); !ok {
+			t.Errorf("%s: unknown sub-region %q", test.name, test.expected.SubRegion)
+		}
+	}
+
+	if _, ok := (Location{}).ContinentValue(); ok {
+		t.Error("expected empty continent to be invalid")
+	}
+}
+
+func TestM49(t *testing.T) {
+	seen := make(map[string]string)
+	check := func(name, code string, none bool) {
+		if none {
+			if code != "" {
+				t.Errorf("%s: expected no code, got %q", name, code)
+			}
+			return
+		}
+
+		if len(code) != 3 || strings.Trim(code, "0123456789") != "" {
+			t.Errorf("%s: expected a three digit code, got %q", name, code)
+		}
+		if other, ok := seen[code]; ok {
+			t.Errorf("%s: code %s is already used by %s", name, code, other)
+		}
+		seen[code] = name
+	}
+
+	for _, r := range regions {
+		check(string(r), r.M49(), r == RegionAntarctica)
+	}
+	for _, r := range subRegions {
+		check(string(r), r.M49(), r == SubRegionAntarctica || r == SubRegionSevenSeas)
+	}
+
+	if code := Region("Atlantis").M49(); code != "" {
+		t.Errorf("expected no code for an unknown region, got %q", code)
+	}
+
+	// Every country in Natural Earth with a region has its codes.
+	r, err := New(Countries110)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, loc := range r.locs {
+		if region, _ := loc.RegionValue(); loc.RegionCode != region.M49() {
+			t.Errorf("%s: expected region code %q, got %q", loc.Country, region.M49(), loc.RegionCode)
+		}
+		if sub, _ := loc.SubRegionValue(); loc.SubRegionCode != sub.M49() {
+			t.Errorf("%s: expected sub-region code %q, got %q", loc.Country, sub.M49(), loc.SubRegionCode)
+		}
+		if loc.Region != "" && loc.Region != string(RegionAntarctica) && loc.RegionCode == "" {
+			t.Errorf("%s: no code for region %q", loc.Country, loc.Region)
+		}
+	}
+}
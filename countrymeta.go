@@ -0,0 +1,108 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	_ "embed"
+	"encoding/csv"
+	"strings"
+	"sync"
+)
+
+// countryMetadataCSV is the table used by WithCountryMetadata. It's only
+// referenced from there so the linker strips it out of programs which don't
+// use it, like the datasets in embed.go.
+//
+//go:embed data/country_metadata.csv
+var countryMetadataCSV string
+
+// countryMetadata is a row of countryMetadataCSV.
+type countryMetadata struct {
+	callingCode, currencyCode string
+}
+
+var (
+	countryMetadataByCode     map[string]countryMetadata
+	countryMetadataByCodeOnce sync.Once
+)
+
+// WithCountryMetadata fills in the CallingCode and CurrencyCode of each
+// Location from a table built into rgeo, rather than from the datasets, using
+// the first of its CountryCode2, CountryCode3 and CountryCodeNumeric which is
+// in the table. The numeric code is needed for France and Norway, which don't
+// have alpha codes in the Natural Earth data. Places without any ISO 3166-1
+// code, like Kosovo, are left without them, as are shapes without any country
+// fields, like those in Cities10. Some uninhabited territories, like Heard
+// Island and McDonald Islands, have a currency but no calling code.
+//
+// The calling codes are the E.164 country codes assigned by the ITU, so the
+// countries of the North American Numbering Plan all have "+1" and are told
+// apart by their area codes. The currencies are the ISO 4217 code of the
+// official currency, or of the main one where there are several, e.g. "PAB"
+// for Panama, which also uses the US dollar. The table is in
+// data/country_metadata.csv, and was last updated in 2026 for the Caribbean
+// guilder and Bulgaria's adoption of the euro. It's updated by hand when
+// either list changes, which happens a few times a decade.
+//
+// The table is around 5KB, it's only included in programs which use this
+// option.
+func WithCountryMetadata() Option {
+	return func(o *options) {
+		o.countryMetadata = lookupCountryMetadata
+	}
+}
+
+// lookupCountryMetadata sets the CallingCode and CurrencyCode of l from
+// countryMetadataCSV.
+func lookupCountryMetadata(l Location) Location {
+	countryMetadataByCodeOnce.Do(func() {
+		countryMetadataByCode = parseCountryMetadata(countryMetadataCSV)
+	})
+
+	for _, code := range []string{l.CountryCode2, l.CountryCode3, l.CountryCodeNumeric} {
+		if m, ok := countryMetadataByCode[code]; ok && code != "" {
+			l.CallingCode = m.callingCode
+			l.CurrencyCode = m.currencyCode
+
+			return l
+		}
+	}
+
+	return l
+}
+
+// parseCountryMetadata reads the table, keyed by each of the country codes.
+// The table is built in, so it panics if it's invalid.
+func parseCountryMetadata(table string) map[string]countryMetadata {
+	rows, err := csv.NewReader(strings.NewReader(table)).ReadAll()
+	if err != nil {
+		panic("rgeo: invalid country metadata: " + err.Error())
+	}
+
+	ret := make(map[string]countryMetadata, 3*len(rows))
+	for _, row := range rows[1:] {
+		m := countryMetadata{currencyCode: row[4]}
+		if row[3] != "" {
+			m.callingCode = "+" + row[3]
+		}
+
+		for _, code := range row[:3] {
+			ret[code] = m
+		}
+	}
+
+	return ret
+}
@@ -0,0 +1,115 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"encoding/csv"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+func TestWithCountryMetadata(t *testing.T) {
+	r, err := NewWithOptions([]func() []byte{Countries110}, WithCountryMetadata())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name              string
+		in                orb.Point
+		calling, currency string
+	}{
+		{"Alpha2", orb.Point{-0.1276, 51.5072}, "+44", "GBP"},
+		{"NumericOnly", orb.Point{2.3522, 48.8566}, "+33", "EUR"},
+		{"NANP", orb.Point{-79.38, 43.65}, "+1", "CAD"},
+		{"NoCode", orb.Point{21.17, 42.67}, "", ""},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			loc, err := r.ReverseGeocode(test.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if loc.CallingCode != test.calling || loc.CurrencyCode != test.currency {
+				t.Errorf("expected: %q %q, got: %q %q", test.calling, test.currency, loc.CallingCode, loc.CurrencyCode)
+			}
+		})
+	}
+
+	// It's off by default.
+	plain, err := New(Countries110)
+	if err != nil {
+		t.Fatal(err)
+	}
+	loc, err := plain.ReverseGeocode(orb.Point{-0.1276, 51.5072})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loc.CallingCode != "" || loc.CurrencyCode != "" {
+		t.Errorf("expected no metadata, got: %q %q", loc.CallingCode, loc.CurrencyCode)
+	}
+}
+
+func TestCountryMetadataTable(t *testing.T) {
+	rows, err := csv.NewReader(strings.NewReader(countryMetadataCSV)).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := deep.Equal([]string{"alpha2", "alpha3", "numeric", "calling_code", "currency_code"}, rows[0]); diff != nil {
+		t.Error(diff)
+	}
+
+	seen := make(map[string]bool)
+	for _, row := range rows[1:] {
+		if len(row[0]) != 2 || len(row[1]) != 3 || len(row[2]) != 3 || len(row[4]) != 3 {
+			t.Errorf("malformed row: %v", row)
+		}
+		for _, code := range row[:3] {
+			if seen[code] {
+				t.Errorf("duplicate code: %s", code)
+			}
+			seen[code] = true
+		}
+	}
+
+	// The codes should match the ones in the Natural Earth data wherever it
+	// has all three.
+	r, err := New(Countries10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	table := make(map[string][]string)
+	for _, row := range rows[1:] {
+		table[row[0]] = row
+	}
+
+	for _, l := range r.locs {
+		row, ok := table[l.CountryCode2]
+		if !ok || l.CountryCode3 == "-99" || l.CountryCodeNumeric == "" {
+			continue
+		}
+		if row[1] != l.CountryCode3 || row[2] != l.CountryCodeNumeric {
+			t.Errorf("%s: table has %s %s, data has %s %s", l.Country, row[1], row[2], l.CountryCode3, l.CountryCodeNumeric)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+// CountryCovering returns the s2 cells of the given level which cover the
+// country containing loc, e.g. to shard work by country or to join with other
+// data indexed by s2 cell. The country is the shape containing loc with the
+// country fields and nothing finer, so one of the country datasets, like
+// Countries10, has to be loaded: the shapes of Provinces10 are provinces,
+// even though they have the country fields. If there is no such shape it
+// returns ErrLocationNotFound.
+//
+// Every cell is at the given level, so the number of cells grows about four
+// times with each level, e.g. the covering of France in Countries10, with its
+// overseas regions, is 15 cells at level 4 but over 10,000 at level 10, and
+// takes correspondingly longer to compute. Use CountryCoveringWith to set a
+// range of levels and a maximum number of cells.
+func (r *Rgeo) CountryCovering(loc orb.Point, level int) (s2.CellUnion, error) {
+	if level < 0 || level > s2.MaxLevel {
+		return nil, fmt.Errorf("invalid level: %d", level)
+	}
+
+	return r.CountryCoveringWith(loc, &s2.RegionCoverer{MinLevel: level, MaxLevel: level})
+}
+
+// CountryCoveringWith works like CountryCovering, but with the given coverer,
+// which sets the range of levels of the cells and the maximum number of them,
+// see s2.RegionCoverer. The coverer isn't changed, so it can be reused.
+func (r *Rgeo) CountryCoveringWith(loc orb.Point, coverer *s2.RegionCoverer) (s2.CellUnion, error) {
+	if coverer == nil {
+		return nil, errors.New("missing parameter: coverer")
+	}
+
+	p, ok := r.countryShape(pointFromCoord(loc))
+	if !ok {
+		return nil, ErrLocationNotFound
+	}
+
+	return coverer.Covering(p), nil
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+func TestCountryCovering(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, levelsGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The country is the shape with only the country fields, even inside the
+	// province and the town.
+	for _, in := range []orb.Point{{3.5, 0.5}, {1.5, 3.5}} {
+		cells, err := r.CountryCovering(in, 8)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		for _, id := range cells {
+			if id.Level() != 8 {
+				t.Errorf("expected level 8, got: %d", id.Level())
+			}
+		}
+
+		// The covering contains the whole of the country, including the
+		// corner furthest from the province.
+		for _, p := range []orb.Point{{0.1, 0.1}, {3.9, 0.1}, {3.9, 3.9}} {
+			if !cells.ContainsPoint(pointFromCoord(p)) {
+				t.Errorf("%v: expected the covering to contain %v", in, p)
+			}
+		}
+	}
+
+	cells, err := r.CountryCoveringWith(orb.Point{3.5, 0.5}, &s2.RegionCoverer{MaxLevel: 10, MaxCells: 4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(cells) > 4 {
+		t.Errorf("expected at most 4 cells, got: %d", len(cells))
+	}
+
+	if _, err := r.CountryCovering(orb.Point{10, 10}, 8); !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("expected error: %v\n got: %v\n", ErrLocationNotFound, err)
+	}
+
+	if _, err := r.CountryCovering(orb.Point{3.5, 0.5}, 31); err == nil {
+		t.Error("expected error for invalid level")
+	}
+
+	// The shapes of a province dataset aren't countries, even with the
+	// country fields.
+	provinceGeo := `{
+		"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"ADMIN":"Land","name":"North"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,52],[1,52],[1,53],[0,53],[0,52]]]}}
+		]
+	}`
+	provinces, err := New(func() []byte { return compressData(t, provinceGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := provinces.CountryCovering(orb.Point{0.5, 52.5}, 8); !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("expected error: %v\n got: %v\n", ErrLocationNotFound, err)
+	}
+}
@@ -27,31 +27,63 @@ Usage
 
 The variable containing the data will be named outfile.
 
+With the -url flag the inputs (including the one given to -merge) are
+downloaded over HTTP(S) rather than read from local files:
+
+	go run datagen.go -url -o outfile.go https://example.com/infile.geojson
+
+To check a download against a known SHA-256 checksum, add it to the end of the
+URL as a fragment, like https://example.com/infile.geojson#sha256=<hex>.
+
+Alongside outfile.gz, datagen writes outfile.sha256 with the hex encoded SHA-256
+of the compressed data, which is what rgeo.DatasetChecksum returns for the
+included datasets.
+
+The output is gzipped by default. With -codec zstd it's compressed with the
+zstd command instead and written to outfile.zst, which decompresses faster,
+but needs a zstd Decompressor to be added with rgeo.RegisterDecompressor
+before it can be loaded.
+
 rgeo reads the location information from the following GeoJSON properties:
 
 	- Country:      "ADMIN" or "admin"
 	- CountryLong:  "FORMAL_EN"
+	- Sovereign:    "SOVEREIGNT"
 	- CountryCode2: "ISO_A2"
 	- CountryCode3: "ISO_A3"
+	- CountryCodeNumeric: "ISO_N3" or "ISO_N3_EH"
 	- Continent:    "CONTINENT"
 	- Region:       "REGION_UN"
 	- SubRegion:    "SUBREGION"
+	- RegionCode and SubRegionCode: the UN M49 codes of the Region and
+	                SubRegion, which Natural Earth doesn't have itself
 	- Province:     "name"
 	- ProvinceCode: "iso_3166_2"
+	- County:       "NAME", for admin-2 features (TYPE "County" or FEATURECLA
+	                "Admin-2 scale rank")
+	- CountyCode:   "ADM2_CODE", for admin-2 features
 	- City:         "name_conve"
+	- FeatureID:    "NE_ID" or "ne_id", or the properties given to
+	                rgeo.WithFeatureIDProperty
 */
 package main
 
 import (
 	"bytes"
 	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"flag"
 	"fmt"
 	"io"
 	"log"
+	"net/http"
+	"net/url"
 	"os"
+	"os/exec"
 	"strings"
+	"time"
 
 	"github.com/twpayne/go-geom/encoding/geojson"
 )
@@ -61,6 +93,8 @@ func main() {
 	outFileName := flag.String("o", "", "Path to output file")
 	neCommentFlag := flag.Bool("ne", false, "Use Natural earth comment")
 	mergeFileName := flag.String("merge", "", "File to get extra info from")
+	urlFlag := flag.Bool("url", false, "Download the input files from URLs")
+	codecFlag := flag.String("codec", "gzip", "Compression for the output, gzip or zstd (runs the zstd command)")
 
 	flag.Parse()
 
@@ -69,13 +103,18 @@ func main() {
 		return
 	}
 
-	feats, err := readInputs(flag.Args(), *mergeFileName)
+	open := openFile
+	if *urlFlag {
+		open = download
+	}
+
+	feats, err := readInputs(flag.Args(), *mergeFileName, open)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	var pre string
-	if *neCommentFlag {
+	if *neCommentFlag && !*urlFlag {
 		pre = "https://github.com/nvkelso/natural-earth-vector/blob/master/geojson/"
 	}
 
@@ -84,26 +123,30 @@ func main() {
 		files = append(files, *mergeFileName)
 	}
 
+	if *urlFlag {
+		for i, f := range files {
+			files[i], _ = splitChecksum(f)
+		}
+	}
+
 	resp, err := json.Marshal(feats)
 	if err != nil {
 		log.Fatal(err)
 	}
 
 	// Compress data
-	var buf bytes.Buffer
-	zw, _ := gzip.NewWriterLevel(&buf, 9)
-
-	if _, err := zw.Write(resp); err != nil {
+	data, ext, err := compress(resp, *codecFlag)
+	if err != nil {
 		log.Fatal(err)
 	}
-	zw.Flush()
-	if err := zw.Close(); err != nil {
+
+	sum := sha256.Sum256(data)
+
+	if err := os.WriteFile(fmt.Sprintf("%s.%s", *outFileName, ext), data, 0o644); err != nil {
 		log.Fatal(err)
 	}
 
-	f, _ := os.Create(fmt.Sprintf("%s.gz", *outFileName))
-	_, err = io.Copy(f, &buf)
-	if err != nil {
+	if err := os.WriteFile(fmt.Sprintf("%s.sha256", *outFileName), []byte(hex.EncodeToString(sum[:])), 0o644); err != nil {
 		log.Fatal(err)
 	}
 
@@ -111,13 +154,49 @@ func main() {
 	fmt.Fprintf(fReadme, "%s %s", strings.TrimSuffix(*outFileName, ".go"), "uses data from "+printSlice(prefixSlice(pre, files)))
 }
 
-func readInputs(in []string, mergeFileName string) (*geojson.FeatureCollection, error) {
+// compress compresses data with the given codec, returning it along with the
+// file extension to use. zstd is done by running the zstd command, so that
+// datagen doesn't need any more dependencies.
+func compress(data []byte, codec string) ([]byte, string, error) {
+	switch codec {
+	case "gzip":
+		var buf bytes.Buffer
+		zw, _ := gzip.NewWriterLevel(&buf, 9)
+
+		if _, err := zw.Write(data); err != nil {
+			return nil, "", err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, "", err
+		}
+
+		return buf.Bytes(), "gz", nil
+	case "zstd":
+		cmd := exec.Command("zstd", "-19", "-q", "-c")
+		cmd.Stdin = bytes.NewReader(data)
+		cmd.Stderr = os.Stderr
+
+		out, err := cmd.Output()
+		if err != nil {
+			return nil, "", fmt.Errorf("running zstd: %w", err)
+		}
+
+		return out, "zst", nil
+	}
+
+	return nil, "", fmt.Errorf("unknown codec %q, needs gzip or zstd", codec)
+}
+
+// opener opens an input, either openFile or download.
+type opener func(name string) (io.ReadCloser, error)
+
+func readInputs(in []string, mergeFileName string, open opener) (*geojson.FeatureCollection, error) {
 	fc := new(geojson.FeatureCollection)
 
 	var mergeData *geojson.FeatureCollection
 
 	if mergeFileName != "" {
-		md, err := readInput(mergeFileName, nil)
+		md, err := readInput(mergeFileName, nil, open)
 		if err != nil {
 			return nil, err
 		}
@@ -126,7 +205,7 @@ func readInputs(in []string, mergeFileName string) (*geojson.FeatureCollection,
 	}
 
 	for _, f := range in {
-		s, err := readInput(f, mergeData)
+		s, err := readInput(f, mergeData, open)
 		if err != nil {
 			return nil, err
 		}
@@ -137,9 +216,9 @@ func readInputs(in []string, mergeFileName string) (*geojson.FeatureCollection,
 	return fc, nil
 }
 
-func readInput(f string, mergeData *geojson.FeatureCollection) (*geojson.FeatureCollection, error) {
+func readInput(f string, mergeData *geojson.FeatureCollection, open opener) (*geojson.FeatureCollection, error) {
 	// Open infile
-	infile, err := os.Open(f)
+	infile, err := open(f)
 	if err != nil {
 		return nil, err
 	}
@@ -175,6 +254,64 @@ func readInput(f string, mergeData *geojson.FeatureCollection) (*geojson.Feature
 	return &fc, nil
 }
 
+// openFile opens a local input file.
+func openFile(name string) (io.ReadCloser, error) {
+	return os.Open(name)
+}
+
+// download fetches an input from a URL, checking the length of the response
+// against the Content-Length header and, if the URL has a #sha256=<hex>
+// fragment, its checksum.
+func download(rawURL string) (io.ReadCloser, error) {
+	u, sum := splitChecksum(rawURL)
+
+	client := http.Client{Timeout: 5 * time.Minute}
+
+	resp, err := client.Get(u)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading %s: %s", u, resp.Status)
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("downloading %s: %w", u, err)
+	}
+
+	if resp.ContentLength >= 0 && int64(len(data)) != resp.ContentLength {
+		return nil, fmt.Errorf("downloading %s: got %d bytes, expected %d",
+			u, len(data), resp.ContentLength)
+	}
+
+	if sum != "" {
+		got := sha256.Sum256(data)
+		if !strings.EqualFold(hex.EncodeToString(got[:]), sum) {
+			return nil, fmt.Errorf("downloading %s: sha256 is %x, expected %s", u, got, sum)
+		}
+	}
+
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// splitChecksum splits a URL with a #sha256=<hex> fragment into the URL
+// without the fragment and the checksum. The checksum is empty if there isn't
+// one.
+func splitChecksum(rawURL string) (u, sum string) {
+	parsed, err := url.Parse(rawURL)
+	if err != nil || !strings.HasPrefix(parsed.Fragment, "sha256=") {
+		return rawURL, ""
+	}
+
+	sum = strings.TrimPrefix(parsed.Fragment, "sha256=")
+	parsed.Fragment = ""
+
+	return parsed.String(), sum
+}
+
 // printSlice prints a slice of strings with commas and an ampersand if needed
 func printSlice(in []string) string {
 	n := len(in)
@@ -0,0 +1,260 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"fmt"
+
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/planar"
+)
+
+// ProvinceGeometry returns the outline of the province containing the given
+// coordinate. If the geometry of the province's own dataset is kept it's
+// returned as it is, like GetGeometry. Otherwise, it's dissolved from the
+// geometry of the counties in the province, such as those from US_Counties10,
+// e.g. to draw the states of the United States while only keeping the
+// geometry of the counties with WithGeometryDatasets.
+//
+// The province still has to be loaded, from Provinces10 or similar, as the
+// counties don't say which province they're in, a county is counted as part
+// of it if a point well inside the county is in the province's shape. So the
+// two datasets can have slightly different borders.
+//
+// golang/geo doesn't have polygon unions, so the counties are dissolved by
+// removing the edges they share with each other. This relies on neighbouring
+// counties having the same vertices along their borders, as they do in
+// Natural Earth's data, anywhere they don't is left as a sliver between them.
+// The rings are kept as they are, and not simplified or snapped to the
+// province's own border.
+//
+// The dissolved geometry is worked out the first time it's needed for each
+// province and kept for later calls. It returns ErrLocationNotFound if the
+// coordinate isn't in a province, and an error if the province has no
+// geometry and no counties with geometry.
+func (r *Rgeo) ProvinceGeometry(loc orb.Point) (orb.Geometry, error) {
+	var province s2.Shape
+	for _, shape := range r.containingShapes(pointFromCoord(loc)) {
+		if r.locs[shape].adminLevel() == LevelProvince {
+			province = shape
+			break
+		}
+	}
+	if province == nil {
+		return nil, ErrLocationNotFound
+	}
+
+	for _, name := range r.datasets {
+		if geom := r.geoms[name][province]; geom != nil && r.keepsGeometry(name) {
+			return geom, nil
+		}
+	}
+
+	r.provincesMu.Lock()
+	defer r.provincesMu.Unlock()
+
+	if geom, ok := r.provinces[province]; ok {
+		return geom, nil
+	}
+
+	bound := province.(*s2.Polygon).RectBound()
+
+	var rings []orb.Ring
+	for _, name := range r.datasets {
+		if !r.keepsGeometry(name) {
+			continue
+		}
+
+		for _, shape := range r.order[name] {
+			if r.locs[shape].adminLevel() != LevelCounty {
+				continue
+			}
+
+			p, ok := shape.(*s2.Polygon)
+			if !ok || !bound.Intersects(p.RectBound()) || !province.(*s2.Polygon).ContainsPoint(interiorPoint(p)) {
+				continue
+			}
+
+			rings = append(rings, geometryRings(r.geoms[name][shape])...)
+		}
+	}
+	if len(rings) == 0 {
+		return nil, fmt.Errorf("no geometry for province %q: its dataset doesn't keep it and there are no counties with geometry in it",
+			r.locs[province].Province)
+	}
+
+	geom := dissolveRings(rings)
+
+	if r.provinces == nil {
+		r.provinces = make(map[s2.Shape]orb.Geometry)
+	}
+	r.provinces[province] = geom
+
+	return geom, nil
+}
+
+// interiorPoint returns a point inside p, away from its edges where possible.
+func interiorPoint(p *s2.Polygon) s2.Point {
+	coverer := &s2.RegionCoverer{MaxLevel: s2.MaxLevel, MaxCells: 8}
+	if cells := coverer.InteriorCovering(p); len(cells) > 0 {
+		// The centre of the biggest cell is the furthest from the edges.
+		best := cells[0]
+		for _, c := range cells[1:] {
+			if c.Level() < best.Level() {
+				best = c
+			}
+		}
+
+		return best.Point()
+	}
+
+	return p.Loop(0).Vertex(0)
+}
+
+// geometryRings returns the rings of a Polygon or MultiPolygon, with the outer
+// rings wound counter-clockwise and the holes clockwise, as in RFC 7946.
+// Anything else has no rings.
+func geometryRings(g orb.Geometry) []orb.Ring {
+	var polys []orb.Polygon
+	switch g := g.(type) {
+	case orb.Polygon:
+		polys = []orb.Polygon{g}
+	case orb.MultiPolygon:
+		polys = g
+	}
+
+	var rings []orb.Ring
+	for _, poly := range polys {
+		for i, ring := range poly {
+			ring = append(orb.Ring(nil), ring...)
+			if outer := i == 0; outer != (ring.Orientation() == orb.CCW) {
+				ring.Reverse()
+			}
+			rings = append(rings, ring)
+		}
+	}
+
+	return rings
+}
+
+// dissolvedEdge is a directed edge of a ring, for dissolveRings.
+type dissolvedEdge struct {
+	a, b orb.Point
+}
+
+// dissolveRings returns the union of the polygons made of rings, wound as by
+// geometryRings, by removing each edge which is also in another ring in the
+// opposite direction and joining the rest up into rings again. The result is a
+// Polygon if there's one outer ring, otherwise a MultiPolygon.
+func dissolveRings(rings []orb.Ring) orb.Geometry {
+	count := make(map[dissolvedEdge]int)
+	var edges []dissolvedEdge
+	for _, ring := range rings {
+		for i := 0; i+1 < len(ring); i++ {
+			e := dissolvedEdge{ring[i], ring[i+1]}
+			if e.a == e.b {
+				continue
+			}
+
+			if rev := (dissolvedEdge{e.b, e.a}); count[rev] > 0 {
+				count[rev]--
+				continue
+			}
+
+			count[e]++
+			edges = append(edges, e)
+		}
+	}
+
+	// The remaining edges by their first point, in the order they were found
+	// so the result is the same every time.
+	from := make(map[orb.Point][]dissolvedEdge)
+	for _, e := range edges {
+		if count[e] > 0 {
+			count[e]--
+			from[e.a] = append(from[e.a], e)
+		}
+	}
+
+	var outers, holes []orb.Ring
+	for _, e := range edges {
+		if len(from[e.a]) == 0 || from[e.a][0] != e {
+			continue
+		}
+
+		ring := orb.Ring{e.a}
+		for p := e.a; ; {
+			next := from[p][0]
+			from[p] = from[p][1:]
+
+			ring = append(ring, next.b)
+			p = next.b
+			if p == e.a || len(from[p]) == 0 {
+				break
+			}
+		}
+
+		if len(ring) < 4 || ring[0] != ring[len(ring)-1] {
+			continue
+		}
+		if ring.Orientation() == orb.CCW {
+			outers = append(outers, ring)
+		} else {
+			holes = append(holes, ring)
+		}
+	}
+
+	mp := make(orb.MultiPolygon, len(outers))
+	for i, outer := range outers {
+		mp[i] = orb.Polygon{outer}
+	}
+
+	// Each hole goes in the smallest outer ring around it.
+	for _, hole := range holes {
+		best, bestArea := -1, 0.0
+		for i, outer := range outers {
+			if !planar.RingContains(outer, hole[0]) && !ringHasPoint(outer, hole[0]) {
+				continue
+			}
+
+			if area := planar.Area(outer); best < 0 || area < bestArea {
+				best, bestArea = i, area
+			}
+		}
+
+		if best >= 0 {
+			mp[best] = append(mp[best], hole)
+		}
+	}
+
+	if len(mp) == 1 {
+		return mp[0]
+	}
+
+	return mp
+}
+
+// ringHasPoint reports whether p is one of the vertices of ring.
+func ringHasPoint(ring orb.Ring, p orb.Point) bool {
+	for _, q := range ring {
+		if q == p {
+			return true
+		}
+	}
+
+	return false
+}
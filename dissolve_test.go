@@ -0,0 +1,160 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/planar"
+)
+
+// provinceGeo is a province over 0..2 by 0..1, with another province next to
+// it without any counties.
+const provinceGeo = `{
+	"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"name":"North","iso_3166_2":"LND-N"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,0],[2,0],[2,1],[0,1],[0,0]]]}},
+			{"type":"Feature",
+			"properties":{"name":"South","iso_3166_2":"LND-S"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,-1],[2,-1],[2,0],[0,0],[0,-1]]]}}
+		]
+	}`
+
+// countiesGeo has two counties filling the North province of provinceGeo,
+// the second wound clockwise.
+const countiesGeo = `{
+	"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"TYPE":"County","NAME":"West"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}},
+			{"type":"Feature",
+			"properties":{"TYPE":"County","NAME":"East"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[1,0],[1,1],[2,1],[2,0],[1,0]]]}}
+		]
+	}`
+
+func TestProvinceGeometry(t *testing.T) {
+	provinces := func() []byte { return compressData(t, provinceGeo) }
+	counties := func() []byte { return compressData(t, countiesGeo) }
+
+	r, err := NewWithOptions([]func() []byte{provinces, counties}, WithGeometryDatasets(counties))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	geom, err := r.ProvinceGeometry(orb.Point{0.5, 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	poly, ok := geom.(orb.Polygon)
+	if !ok {
+		t.Fatalf("expected Polygon, got %T", geom)
+	}
+	if len(poly) != 1 {
+		t.Fatalf("expected 1 ring, got %d", len(poly))
+	}
+	if area := planar.Area(poly); area != 2 {
+		t.Errorf("expected area 2, got %f", area)
+	}
+	if poly[0].Orientation() != orb.CCW {
+		t.Error("expected outer ring to be counter-clockwise")
+	}
+	for _, p := range []orb.Point{{0.5, 0.5}, {1.5, 0.5}, {1, 0.5}} {
+		if !planar.PolygonContains(poly, p) {
+			t.Errorf("expected %v to be inside", p)
+		}
+	}
+
+	// The second call, from anywhere in the province, gives the same cached
+	// geometry.
+	again, err := r.ProvinceGeometry(orb.Point{1.5, 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(geom, again); diff != nil {
+		t.Error(diff)
+	}
+	if len(r.provinces) != 1 {
+		t.Errorf("expected 1 cached province, got %d", len(r.provinces))
+	}
+
+	if _, err := r.ProvinceGeometry(orb.Point{1, -0.5}); err == nil || errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("expected error for province without counties, got %v", err)
+	}
+	if _, err := r.ProvinceGeometry(orb.Point{5, 5}); !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("expected ErrLocationNotFound, got %v", err)
+	}
+
+	// With the province's own geometry kept, that's used instead.
+	kept, err := New(provinces, counties)
+	if err != nil {
+		t.Fatal(err)
+	}
+	geom, err = kept.ProvinceGeometry(orb.Point{0.5, 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(orb.Geometry(orb.Polygon{{{0, 0}, {2, 0}, {2, 1}, {0, 1}, {0, 0}}}), geom); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestDissolveRings(t *testing.T) {
+	// A 3x3 grid of squares without the middle one dissolves into a square
+	// with a hole, and a separate square stays on its own.
+	var rings []orb.Ring
+	for x := 0.0; x < 3; x++ {
+		for y := 0.0; y < 3; y++ {
+			if x == 1 && y == 1 {
+				continue
+			}
+			rings = append(rings, orb.Ring{{x, y}, {x + 1, y}, {x + 1, y + 1}, {x, y + 1}, {x, y}})
+		}
+	}
+	rings = append(rings, orb.Ring{{5, 0}, {6, 0}, {6, 1}, {5, 1}, {5, 0}})
+
+	mp, ok := dissolveRings(rings).(orb.MultiPolygon)
+	if !ok {
+		t.Fatalf("expected MultiPolygon, got %T", dissolveRings(rings))
+	}
+	if len(mp) != 2 {
+		t.Fatalf("expected 2 polygons, got %d", len(mp))
+	}
+
+	if len(mp[0]) != 2 {
+		t.Fatalf("expected outer ring and hole, got %d rings", len(mp[0]))
+	}
+	if area := planar.Area(mp[0]); area != 8 {
+		t.Errorf("expected area 8, got %f", area)
+	}
+	if planar.PolygonContains(mp[0], orb.Point{1.5, 1.5}) {
+		t.Error("expected the hole not to be inside")
+	}
+	if area := planar.Area(mp[1]); area != 1 {
+		t.Errorf("expected area 1, got %f", area)
+	}
+}
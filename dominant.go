@@ -0,0 +1,101 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"fmt"
+
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+// dominantSamples is roughly how many points DominantCountry samples in the
+// polygon.
+const dominantSamples = 4096
+
+// DominantCountry returns the country covering the largest part of the given
+// polygon, e.g. to assign a satellite image footprint to one country, along
+// with the fraction of the polygon's area it covers. Only the country fields of
+// the Location are set, from all of the shapes containing each point like in
+// ReverseGeocode, so countries are told apart by all of those fields rather
+// than just the Country name.
+//
+// The fraction is of the whole polygon, so it's less than 1 for a polygon which
+// is partly over the sea, even if it's all in one country, and if no part of
+// the polygon is in a country, e.g. it's all over the sea, it returns
+// ErrLocationNotFound. Ties go to the country found first.
+//
+// The areas are estimated by sampling points on a grid of s2 cells across the
+// polygon, around 4,000 of them whatever its size, so the fraction is only
+// accurate to within a percent or so and a country covering less than that
+// might be missed.
+func (r *Rgeo) DominantCountry(g orb.Polygon) (Location, float64, error) {
+	p, err := polygonFromGeometry(g, options{})
+	if err != nil {
+		return Location{}, 0, fmt.Errorf("bad polygon: %w", err)
+	}
+	if p.NumLoops() == 0 {
+		return Location{}, 0, fmt.Errorf("bad polygon: %w", ErrDegenerateRing)
+	}
+
+	level := s2.AvgAreaMetric.ClosestLevel(p.Area() / dominantSamples)
+	cells := (&s2.RegionCoverer{MinLevel: level, MaxLevel: level}).Covering(p)
+
+	var (
+		total float64
+		areas = make(map[Location]float64)
+		order []Location
+	)
+
+	for _, id := range cells {
+		cell := s2.CellFromCellID(id)
+		centre := cell.Center()
+		if !p.ContainsPoint(centre) {
+			continue
+		}
+
+		area := cell.ApproxArea()
+		total += area
+
+		res := r.containingShapes(centre)
+		if len(res) == 0 {
+			continue
+		}
+
+		loc := r.combineLocations(res).only(levelFields[LevelCountry])
+		if loc == (Location{}) {
+			continue
+		}
+
+		if _, ok := areas[loc]; !ok {
+			order = append(order, loc)
+		}
+		areas[loc] += area
+	}
+
+	if len(order) == 0 {
+		return Location{}, 0, ErrLocationNotFound
+	}
+
+	best := order[0]
+	for _, loc := range order[1:] {
+		if areas[loc] > areas[best] {
+			best = loc
+		}
+	}
+
+	return best, areas[best] / total, nil
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+func TestDominantCountry(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, neighboursGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		in       orb.Polygon
+		expected Location
+		fraction float64
+		err      error
+	}{
+		{
+			"Inside",
+			orb.Polygon{{{0.2, 0.2}, {0.8, 0.2}, {0.8, 0.8}, {0.2, 0.8}, {0.2, 0.2}}},
+			Location{Country: "West"}, 1, nil,
+		},
+		{
+			// Half in East, a quarter in West and a quarter over the sea.
+			"Split",
+			orb.Polygon{{{0.5, 0}, {2.5, 0}, {2.5, 1}, {0.5, 1}, {0.5, 0}}},
+			Location{Country: "East"}, 0.5, nil,
+		},
+		{
+			"Sea",
+			orb.Polygon{{{5, 5}, {6, 5}, {6, 6}, {5, 6}, {5, 5}}},
+			Location{}, 0, ErrLocationNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			loc, fraction, err := r.DominantCountry(test.in)
+			if !errors.Is(err, test.err) {
+				t.Errorf("expected error: %v\n got: %v\n", test.err, err)
+			}
+			if diff := deep.Equal(test.expected, loc); diff != nil {
+				t.Error(diff)
+			}
+			if math.Abs(fraction-test.fraction) > 0.02 {
+				t.Errorf("expected fraction %v, got %v", test.fraction, fraction)
+			}
+		})
+	}
+
+	if _, _, err := r.DominantCountry(orb.Polygon{{{0, 0}, {1, 1}, {0, 0}}}); err == nil {
+		t.Error("expected error for a polygon with no area")
+	}
+}
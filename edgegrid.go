@@ -0,0 +1,183 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"math"
+
+	"github.com/golang/geo/s1"
+	"github.com/golang/geo/s2"
+)
+
+// edgeGridLevel is the s2 cell level edges are bucketed at in an edgeGrid,
+// cells at level 7 are around 50-80km across.
+const edgeGridLevel = 7
+
+// edgeGrid buckets the edges of all of the shapes in an index by s2 cell, to
+// find the nearest edge to a point.
+//
+// This is what s2's ClosestEdgeQuery is for, but in the version of golang/geo
+// used here it only searches the cube faces holding the first two ranges of
+// index cells (EdgeQuery.initCovering stops after the first top-level cell),
+// so for the included datasets it misses edges on the other faces and can
+// return a shape thousands of kilometres further away than the nearest one.
+type edgeGrid struct {
+	index *s2.ShapeIndex
+	cells map[s2.CellID][]edgeRef
+
+	// pad is the width of a grid cell. Edges shorter than this are only added
+	// to the cell of their first vertex so searches are padded by it, longer
+	// edges are added to every cell they cross.
+	pad s1.Angle
+}
+
+// edgeRef identifies an edge in the index.
+type edgeRef struct {
+	shape, edge int32
+}
+
+// newEdgeGrid buckets all of the edges in index.
+func newEdgeGrid(index *s2.ShapeIndex) *edgeGrid {
+	g := &edgeGrid{
+		index: index,
+		cells: make(map[s2.CellID][]edgeRef),
+		pad:   s1.Angle(s2.MinWidthMetric.Value(edgeGridLevel)),
+	}
+
+	for id := int32(0); id < int32(index.Len()); id++ {
+		shape := index.Shape(id)
+		if shape == nil {
+			continue
+		}
+
+		for e := 0; e < shape.NumEdges(); e++ {
+			edge := shape.Edge(e)
+			ref := edgeRef{shape: id, edge: int32(e)}
+
+			if edge.V0.Distance(edge.V1) < g.pad {
+				c := s2.CellFromPoint(edge.V0).ID().Parent(edgeGridLevel)
+				g.cells[c] = append(g.cells[c], ref)
+				continue
+			}
+
+			for _, c := range s2.SimpleRegionCovering(&s2.Polyline{edge.V0, edge.V1}, edge.V0, edgeGridLevel) {
+				g.cells[c] = append(g.cells[c], ref)
+			}
+		}
+	}
+
+	return g
+}
+
+// nearest returns the id of the shape with the edge closest to p and the
+// distance to it, as long as it's less than limit.
+func (g *edgeGrid) nearest(p s2.Point, limit s1.ChordAngle) (shape int32, dist s1.ChordAngle, ok bool) {
+	ref, dist, ok := g.nearestEdge(p, limit, nil)
+	return ref.shape, dist, ok
+}
+
+// nearestEdge returns the edge closest to p out of the shapes for which keep
+// returns true, or all of them if keep is nil, and the distance to it, as long
+// as it's less than limit.
+//
+// It searches discs of growing radius around p, stopping once the closest edge
+// found is inside the disc, as no edge outside of it can be closer.
+func (g *edgeGrid) nearestEdge(p s2.Point, limit s1.ChordAngle, keep func(shape int32) bool) (ref edgeRef, dist s1.ChordAngle, ok bool) {
+	dist = limit
+	seen := make(map[s2.CellID]bool)
+
+	for radius := g.pad; ; radius *= 4 {
+		search := s2.CapFromCenterAngle(p, radius+g.pad)
+		for _, c := range s2.SimpleRegionCovering(search, p, edgeGridLevel) {
+			if seen[c] {
+				continue
+			}
+			seen[c] = true
+
+			for _, r := range g.cells[c] {
+				if keep != nil && !keep(r.shape) {
+					continue
+				}
+
+				e := g.index.Shape(r.shape).Edge(int(r.edge))
+				if d, less := s2.UpdateMinDistance(p, e.V0, e.V1, dist); less {
+					ref, dist, ok = r, d, true
+				}
+			}
+		}
+
+		if (ok && dist.Angle() <= radius) || radius >= math.Pi ||
+			s1.ChordAngleFromAngle(radius) >= limit {
+			return ref, dist, ok
+		}
+	}
+}
+
+// edgeGrid returns the edgeGrid for r, building it the first time it's needed.
+func (r *Rgeo) edgeGrid() *edgeGrid {
+	r.edgesOnce.Do(func() { r.edges = newEdgeGrid(r.index) })
+	return r.edges
+}
+
+// within returns the ids of the shapes with an edge no further than limit from
+// p, in no particular order.
+func (g *edgeGrid) within(p s2.Point, limit s1.ChordAngle) []int32 {
+	found := make(map[int32]bool)
+
+	search := s2.CapFromCenterAngle(p, limit.Angle()+g.pad)
+	for _, c := range s2.SimpleRegionCovering(search, p, edgeGridLevel) {
+		for _, ref := range g.cells[c] {
+			if found[ref.shape] {
+				continue
+			}
+
+			e := g.index.Shape(ref.shape).Edge(int(ref.edge))
+			if s2.DistanceFromSegment(p, e.V0, e.V1) <= limit.Angle() {
+				found[ref.shape] = true
+			}
+		}
+	}
+
+	ids := make([]int32, 0, len(found))
+	for id := range found {
+		ids = append(ids, id)
+	}
+
+	return ids
+}
+
+// distances returns the distance from p to the closest edge of each shape with
+// an edge closer than limit to p, by shape id.
+func (g *edgeGrid) distances(p s2.Point, limit s1.ChordAngle) map[int32]s1.ChordAngle {
+	found := make(map[int32]s1.ChordAngle)
+
+	search := s2.CapFromCenterAngle(p, limit.Angle()+g.pad)
+	for _, c := range s2.SimpleRegionCovering(search, p, edgeGridLevel) {
+		for _, ref := range g.cells[c] {
+			best, ok := found[ref.shape]
+			if !ok {
+				best = limit
+			}
+
+			e := g.index.Shape(ref.shape).Edge(int(ref.edge))
+			if d, less := s2.UpdateMinDistance(p, e.V0, e.V1, best); less {
+				found[ref.shape] = d
+			}
+		}
+	}
+
+	return found
+}
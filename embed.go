@@ -10,6 +10,9 @@ func Cities10() []byte {
 	return cities10
 }
 
+//go:embed data/Cities10.sha256
+var cities10Sum string
+
 //go:embed data/Countries10.gz
 var countries10 []byte
 
@@ -17,6 +20,9 @@ func Countries10() []byte {
 	return countries10
 }
 
+//go:embed data/Countries10.sha256
+var countries10Sum string
+
 //go:embed data/Countries110.gz
 var countries110 []byte
 
@@ -24,6 +30,9 @@ func Countries110() []byte {
 	return countries110
 }
 
+//go:embed data/Countries110.sha256
+var countries110Sum string
+
 //go:embed data/Provinces10.gz
 var provinces10 []byte
 
@@ -31,9 +40,15 @@ func Provinces10() []byte {
 	return provinces10
 }
 
+//go:embed data/Provinces10.sha256
+var provinces10Sum string
+
 //go:embed data/US_Counties10.gz
 var us_counties10 []byte
 
 func US_Counties10() []byte {
 	return us_counties10
 }
+
+//go:embed data/US_Counties10.sha256
+var us_counties10Sum string
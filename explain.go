@@ -0,0 +1,85 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/golang/geo/s1"
+	"github.com/paulmach/orb"
+)
+
+// explainNearMeters is the distance under which ExplainNotFound calls a point
+// just off the edge of a shape rather than far from any.
+const explainNearMeters = 50000
+
+// ExplainNotFound returns a human readable description of why ReverseGeocode
+// does or doesn't find a Location for loc, for diagnosing batches with lots of
+// ErrLocationNotFound. It says whether the coordinate is invalid, how far it is
+// from the nearest shape and what that shape is, e.g. with Countries10
+//
+//	(4.9000, 61.5000) is 9.4 km outside of Norway
+//	(-140.0000, 0.0000) is 887 km from the nearest shape, French Polynesia
+//
+// It searches the whole index for the nearest shape, so it's much slower than
+// ReverseGeocode and shouldn't be used on every point.
+func (r *Rgeo) ExplainNotFound(loc orb.Point) string {
+	pt := fmt.Sprintf("(%.4f, %.4f)", loc[0], loc[1])
+
+	switch {
+	case math.IsNaN(loc[0]) || math.IsNaN(loc[1]) || math.IsInf(loc[0], 0) || math.IsInf(loc[1], 0):
+		return pt + " is not a valid coordinate"
+	case loc[1] < -90 || loc[1] > 90:
+		return fmt.Sprintf("%s has a latitude outside of [-90, 90], are longitude and latitude swapped?", pt)
+	case loc[0] < -180 || loc[0] > 180:
+		return fmt.Sprintf("%s has a longitude outside of [-180, 180]", pt)
+	}
+
+	l, err := r.reverseGeocode(pointFromCoord(loc))
+	switch {
+	case err == nil:
+		return fmt.Sprintf("%s is in %s", pt, describeLocation(l))
+	case !errors.Is(err, ErrLocationNotFound):
+		return fmt.Sprintf("%s: %v", pt, err)
+	}
+
+	shape, dist, ok := r.nearestShape(pointFromCoord(loc), s1.InfChordAngle())
+	if !ok {
+		return pt + " isn't near anything, no shapes are loaded"
+	}
+
+	name := describeLocation(r.locs[shape])
+	if dist <= explainNearMeters {
+		return fmt.Sprintf("%s is %.1f km outside of %s", pt, dist/1000, name)
+	}
+
+	return fmt.Sprintf("%s is %.0f km from the nearest shape, %s", pt, dist/1000, name)
+}
+
+// describeLocation returns a short name for l, for use in messages.
+func describeLocation(l Location) string {
+	if s := l.Format("{city}, {county}, {province}, {country}"); s != "" {
+		return s
+	}
+
+	if s := firstNonEmpty(l.CountryLong, l.CountryCode3, l.CountryCode2); s != "" {
+		return s
+	}
+
+	return "an unnamed shape"
+}
@@ -0,0 +1,76 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"math"
+	"testing"
+
+	"github.com/paulmach/orb"
+)
+
+func TestExplainNotFound(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, squareGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		in       orb.Point
+		expected string
+	}{
+		{
+			name:     "Inside",
+			in:       orb.Point{0.5, 52.5},
+			expected: "(0.5000, 52.5000) is in TST",
+		},
+		{
+			name:     "Near",
+			in:       orb.Point{1.1, 52.5},
+			expected: "(1.1000, 52.5000) is 6.8 km outside of TST",
+		},
+		{
+			name:     "Far",
+			in:       orb.Point{0.5, 40},
+			expected: "(0.5000, 40.0000) is 1334 km from the nearest shape, TST",
+		},
+		{
+			name:     "Swapped",
+			in:       orb.Point{52.5, 100},
+			expected: "(52.5000, 100.0000) has a latitude outside of [-90, 90], are longitude and latitude swapped?",
+		},
+		{
+			name:     "Longitude",
+			in:       orb.Point{200, 0},
+			expected: "(200.0000, 0.0000) has a longitude outside of [-180, 180]",
+		},
+		{
+			name:     "NaN",
+			in:       orb.Point{math.NaN(), 0},
+			expected: "(NaN, 0.0000) is not a valid coordinate",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if got := r.ExplainNotFound(test.in); got != test.expected {
+				t.Errorf("expected: %s\n got: %s\n", test.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,97 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/geojson"
+)
+
+// ReverseGeocodeFeature works like ReverseGeocodeWithGeometry, but returns the
+// result as a GeoJSON Feature, e.g. for showing it in a mapping tool. The
+// geometry is the one matched in the given dataset and the properties are the
+// non-empty Location fields, using the same names as the Location JSON.
+//
+// The geometry is shared with the Rgeo and must not be modified.
+func (r *Rgeo) ReverseGeocodeFeature(loc orb.Point, dataset string) (*geojson.Feature, error) {
+	res, err := r.ReverseGeocodeWithGeometry(loc, dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	return locationFeature(res.Location, res.Geometry), nil
+}
+
+// ExportGeoJSONTo writes the shapes loaded from the given dataset to w as a
+// GeoJSON FeatureCollection, with the same properties as ReverseGeocodeFeature.
+// The features are written one at a time, so the whole collection is never
+// held in memory, which matters for datasets like Provinces10. They're in the
+// same order as DatasetShapes, so exporting the same data always gives the
+// same output. Features left out by WithFeatureFilter aren't written.
+//
+// If writing fails part of the way through, w is left with invalid GeoJSON.
+func (r *Rgeo) ExportGeoJSONTo(w io.Writer, dataset string) error {
+	shpGeoms, ok := r.geoms[dataset]
+	if !ok {
+		return fmt.Errorf("dataset not found: %q (have %v)", dataset, r.DatasetNames())
+	}
+	if !r.keepsGeometry(dataset) {
+		return r.errNoGeometry(dataset)
+	}
+
+	bw := bufio.NewWriter(w)
+	enc := json.NewEncoder(bw)
+
+	if _, err := io.WriteString(bw, `{"type":"FeatureCollection","features":[`); err != nil {
+		return err
+	}
+
+	for i, shape := range r.order[dataset] {
+		if i > 0 {
+			if err := bw.WriteByte(','); err != nil {
+				return err
+			}
+		}
+
+		if err := enc.Encode(locationFeature(r.locs[shape], shpGeoms[shape])); err != nil {
+			return fmt.Errorf("encoding feature %d: %w", i, err)
+		}
+	}
+
+	if _, err := io.WriteString(bw, "]}\n"); err != nil {
+		return err
+	}
+
+	return bw.Flush()
+}
+
+// locationFeature returns a GeoJSON Feature with the given geometry and the
+// non-empty fields of l as properties, named as in the Location JSON.
+func locationFeature(l Location, g orb.Geometry) *geojson.Feature {
+	f := geojson.NewFeature(g)
+	for name, field := range formatFields {
+		if v := field(l); v != "" {
+			f.Properties[name] = v
+		}
+	}
+
+	return f
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/geojson"
+)
+
+func TestReverseGeocodeFeature(t *testing.T) {
+	square := orb.Polygon{{{0, 52}, {1, 52}, {1, 53}, {0, 53}, {0, 52}}}
+
+	fc := geojson.NewFeatureCollection()
+	fc.Append(geojson.NewFeature(square))
+	fc.Features[0].Properties["ADMIN"] = "Test"
+	fc.Features[0].Properties["ISO_A3"] = "TST"
+
+	r, err := NewFromFeatureCollection(fc, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	f, err := r.ReverseGeocodeFeature(orb.Point{0.5, 52.5}, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := deep.Equal(geojson.Properties{"country": "Test", "country_code_3": "TST"}, f.Properties); diff != nil {
+		t.Error(diff)
+	}
+	if diff := deep.Equal(orb.Geometry(square), f.Geometry); diff != nil {
+		t.Error(diff)
+	}
+
+	// The feature should survive a round trip through JSON.
+	b, err := json.Marshal(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := geojson.UnmarshalFeature(b); err != nil {
+		t.Error(err)
+	}
+
+	if _, err := r.ReverseGeocodeFeature(orb.Point{5, 5}, "test"); !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("expected error: %s\n got: %s\n", ErrLocationNotFound, err)
+	}
+	if _, err := r.ReverseGeocodeFeature(orb.Point{0.5, 52.5}, "other"); err == nil {
+		t.Error("expected error for unknown dataset")
+	}
+}
+
+func TestExportGeoJSONTo(t *testing.T) {
+	squares := func() []byte { return compressData(t, twoSquaresGeo) }
+	square := func() []byte { return compressData(t, squareGeo) }
+
+	r, err := New(square, squares)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := r.ExportGeoJSONTo(&buf, getFunctionName(squares)); err != nil {
+		t.Fatal(err)
+	}
+
+	fc, err := geojson.UnmarshalFeatureCollection(buf.Bytes())
+	if err != nil {
+		t.Fatalf("invalid GeoJSON: %s\n%s", err, buf.String())
+	}
+
+	var got []geojson.Properties
+	for _, f := range fc.Features {
+		got = append(got, f.Properties)
+	}
+	expected := []geojson.Properties{
+		{"country": "West", "country_code_3": "WST"},
+		{"country": "East", "country_code_3": "EST"},
+	}
+	if diff := deep.Equal(expected, got); diff != nil {
+		t.Error(diff)
+	}
+	if diff := deep.Equal(orb.Geometry(orb.Polygon{{{2, 0}, {3, 0}, {3, 1}, {2, 1}, {2, 0}}}), fc.Features[1].Geometry); diff != nil {
+		t.Error(diff)
+	}
+
+	// A dataset with nothing loaded still gives a valid FeatureCollection.
+	none := WithFeatureFilter(func(map[string]interface{}) bool { return false })
+	empty, err := NewWithOptions([]func() []byte{squares}, none)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf.Reset()
+	if err := empty.ExportGeoJSONTo(&buf, getFunctionName(squares)); err != nil {
+		t.Fatal(err)
+	}
+	if fc, err := geojson.UnmarshalFeatureCollection(buf.Bytes()); err != nil || len(fc.Features) != 0 {
+		t.Errorf("expected an empty FeatureCollection, got: %s, %v", buf.String(), err)
+	}
+
+	if err := r.ExportGeoJSONTo(&buf, "other"); err == nil {
+		t.Error("expected error for unknown dataset")
+	}
+}
@@ -0,0 +1,126 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+// Field is a set of Location fields, used with WithFields and returned by
+// Location.Fields. Fields can be combined with |, e.g.
+// FieldCountry|FieldCountryCode3.
+type Field uint32
+
+// The Location fields that can be selected with WithFields.
+const (
+	FieldCountry Field = 1 << iota
+	FieldCountryLong
+	FieldSovereign
+	FieldCountryCode2
+	FieldCountryCode3
+	FieldCountryCodeNumeric
+	FieldContinent
+	FieldRegion
+	FieldSubRegion
+	FieldProvince
+	FieldProvinceCode
+	FieldCounty
+	FieldCity
+	FieldCountyCode
+	FieldFeatureID
+	FieldCallingCode
+	FieldCurrencyCode
+	FieldRegionCode
+	FieldSubRegionCode
+)
+
+// WithFields only keeps the given Location fields in memory, the rest are
+// always empty in results. Each shape holds a copy of its Location, so if you
+// only need one or two fields this can save a lot of memory with larger
+// datasets like Provinces10 and Cities10.
+//
+// A coordinate inside shapes that only have the dropped fields still gives a
+// Location, but it's empty. By default all fields are kept.
+func WithFields(fields ...Field) Option {
+	return func(o *options) {
+		for _, f := range fields {
+			o.fields |= f
+		}
+	}
+}
+
+// Fields returns the set of fields which aren't empty in l, e.g. to check
+// which fields a dataset fills in, or to test for a field with
+// l.Fields()&FieldCity != 0. It doesn't allocate.
+func (l Location) Fields() Field {
+	var f Field
+	set := func(field Field, s string) {
+		if s != "" {
+			f |= field
+		}
+	}
+
+	set(FieldCountry, l.Country)
+	set(FieldCountryLong, l.CountryLong)
+	set(FieldSovereign, l.Sovereign)
+	set(FieldCountryCode2, l.CountryCode2)
+	set(FieldCountryCode3, l.CountryCode3)
+	set(FieldCountryCodeNumeric, l.CountryCodeNumeric)
+	set(FieldContinent, l.Continent)
+	set(FieldRegion, l.Region)
+	set(FieldSubRegion, l.SubRegion)
+	set(FieldProvince, l.Province)
+	set(FieldProvinceCode, l.ProvinceCode)
+	set(FieldCounty, l.County)
+	set(FieldCity, l.City)
+	set(FieldCountyCode, l.CountyCode)
+	set(FieldFeatureID, l.FeatureID)
+	set(FieldCallingCode, l.CallingCode)
+	set(FieldCurrencyCode, l.CurrencyCode)
+	set(FieldRegionCode, l.RegionCode)
+	set(FieldSubRegionCode, l.SubRegionCode)
+
+	return f
+}
+
+// only returns l with every field not in f set to "".
+func (l Location) only(f Field) Location {
+	pick := func(field Field, s string) string {
+		if f&field == 0 {
+			return ""
+		}
+
+		return s
+	}
+
+	return Location{
+		Country:            pick(FieldCountry, l.Country),
+		CountryLong:        pick(FieldCountryLong, l.CountryLong),
+		Sovereign:          pick(FieldSovereign, l.Sovereign),
+		CountryCode2:       pick(FieldCountryCode2, l.CountryCode2),
+		CountryCode3:       pick(FieldCountryCode3, l.CountryCode3),
+		CountryCodeNumeric: pick(FieldCountryCodeNumeric, l.CountryCodeNumeric),
+		CallingCode:        pick(FieldCallingCode, l.CallingCode),
+		CurrencyCode:       pick(FieldCurrencyCode, l.CurrencyCode),
+		Continent:          pick(FieldContinent, l.Continent),
+		Region:             pick(FieldRegion, l.Region),
+		SubRegion:          pick(FieldSubRegion, l.SubRegion),
+		RegionCode:         pick(FieldRegionCode, l.RegionCode),
+		SubRegionCode:      pick(FieldSubRegionCode, l.SubRegionCode),
+		Province:           pick(FieldProvince, l.Province),
+		ProvinceCode:       pick(FieldProvinceCode, l.ProvinceCode),
+		County:             pick(FieldCounty, l.County),
+		City:               pick(FieldCity, l.City),
+		CountyCode:         pick(FieldCountyCode, l.CountyCode),
+		FeatureID:          pick(FieldFeatureID, l.FeatureID),
+	}
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+func TestWithFields(t *testing.T) {
+	r, err := NewWithOptions(
+		[]func() []byte{func() []byte { return compressData(t, twoSquaresGeo) }},
+		WithFields(FieldCountryCode3),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := r.ReverseGeocode(orb.Point{0.5, 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(Location{CountryCode3: "WST"}, result); diff != nil {
+		t.Error(diff)
+	}
+}
+
+// locStringBytes returns the total length of the strings in the Locations held
+// by r.
+func locStringBytes(r *Rgeo) (n int) {
+	for _, loc := range r.locs {
+		v := reflect.ValueOf(loc)
+		for i := 0; i < v.NumField(); i++ {
+			n += v.Field(i).Len()
+		}
+	}
+
+	return n
+}
+
+func TestWithFields_Savings(t *testing.T) {
+	all, err := New(Countries110)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	trimmed, err := NewWithOptions([]func() []byte{Countries110}, WithFields(FieldCountryCode3))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	before, after := locStringBytes(all), locStringBytes(trimmed)
+	t.Logf("Location strings in Countries110: %d bytes, with only CountryCode3: %d bytes", before, after)
+
+	if after >= before/10 {
+		t.Errorf("expected WithFields(FieldCountryCode3) to keep under a tenth of %d bytes, kept %d", before, after)
+	}
+}
+
+func TestLocationFields(t *testing.T) {
+	if f := (Location{}).Fields(); f != 0 {
+		t.Errorf("expected no fields for an empty Location, got: %b", f)
+	}
+
+	loc := Location{Country: "France", CountryCode3: "FRA", City: "Paris"}
+	if f, expected := loc.Fields(), FieldCountry|FieldCountryCode3|FieldCity; f != expected {
+		t.Errorf("expected: %b\n got: %b\n", expected, f)
+	}
+
+	// Every field should have its own bit, which only keeps that field.
+	full := testdata[0].expected
+	full.County, full.CountyCode, full.City = "County", "County code", "City"
+	full.FeatureID, full.CallingCode, full.CurrencyCode = "1", "+1", "USD"
+	for f := FieldCountry; f <= FieldSubRegionCode; f <<= 1 {
+		if got := full.only(f).Fields(); got != f {
+			t.Errorf("expected: %b\n got: %b\n", f, got)
+		}
+	}
+
+	if n := testing.AllocsPerRun(100, func() { _ = loc.Fields() }); n != 0 {
+		t.Errorf("expected no allocations, got: %v", n)
+	}
+}
@@ -0,0 +1,48 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+// regionalIndicatorA is the regional indicator symbol for the letter A, the
+// others follow it in order.
+const regionalIndicatorA = 0x1F1E6
+
+// FlagEmoji returns the flag emoji for l.CountryCode2, e.g. "🇫🇷" for "FR",
+// which is the pair of regional indicator symbols for its letters. It returns
+// "" if the code isn't two letters, like the "-99" Natural Earth uses for
+// places without one. Codes that aren't assigned to a country still give a
+// pair of symbols, which are shown as the letters where there's no flag.
+func (l Location) FlagEmoji() string {
+	code := l.CountryCode2
+	if len(code) != 2 {
+		return ""
+	}
+
+	flag := make([]rune, 0, 2)
+	for i := 0; i < len(code); i++ {
+		c := code[i]
+		if c >= 'a' && c <= 'z' {
+			c -= 'a' - 'A'
+		}
+
+		if c < 'A' || c > 'Z' {
+			return ""
+		}
+
+		flag = append(flag, rune(regionalIndicatorA+int(c-'A')))
+	}
+
+	return string(flag)
+}
@@ -0,0 +1,41 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import "testing"
+
+func TestFlagEmoji(t *testing.T) {
+	tests := []struct {
+		code     string
+		expected string
+	}{
+		{"FR", "🇫🇷"},
+		{"US", "🇺🇸"},
+		{"gb", "🇬🇧"},
+		{"-99", ""},
+		{"", ""},
+		{"F", ""},
+		{"FRA", ""},
+		{"F1", ""},
+		{"É", ""},
+	}
+
+	for _, test := range tests {
+		if got := (Location{CountryCode2: test.code}).FlagEmoji(); got != test.expected {
+			t.Errorf("%q: expected %q, got %q", test.code, test.expected, got)
+		}
+	}
+}
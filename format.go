@@ -0,0 +1,172 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import "strings"
+
+// formatFields maps the placeholder names used by Format to the Location
+// fields, the names are the same as the JSON field names.
+var formatFields = map[string]func(Location) string{
+	"country":              func(l Location) string { return l.Country },
+	"country_long":         func(l Location) string { return l.CountryLong },
+	"sovereign":            func(l Location) string { return l.Sovereign },
+	"country_code_2":       func(l Location) string { return l.CountryCode2 },
+	"country_code_3":       func(l Location) string { return l.CountryCode3 },
+	"country_code_numeric": func(l Location) string { return l.CountryCodeNumeric },
+	"calling_code":         func(l Location) string { return l.CallingCode },
+	"currency_code":        func(l Location) string { return l.CurrencyCode },
+	"continent":            func(l Location) string { return l.Continent },
+	"region":               func(l Location) string { return l.Region },
+	"subregion":            func(l Location) string { return l.SubRegion },
+	"region_code":          func(l Location) string { return l.RegionCode },
+	"subregion_code":       func(l Location) string { return l.SubRegionCode },
+	"province":             func(l Location) string { return l.Province },
+	"province_code":        func(l Location) string { return l.ProvinceCode },
+	"county":               func(l Location) string { return l.County },
+	"county_code":          func(l Location) string { return l.CountyCode },
+	"city":                 func(l Location) string { return l.City },
+	"feature_id":           func(l Location) string { return l.FeatureID },
+}
+
+// Format returns the Location formatted with the given template, for example
+//
+//	l.Format("{city}, {province}, {country}")
+//
+// gives "Paris, Île-de-France, France". Placeholders are the JSON names of the
+// Location fields in braces:
+//
+//	{country} {country_long} {sovereign} {country_code_2} {country_code_3}
+//	{country_code_numeric} {calling_code} {currency_code} {continent}
+//	{region} {subregion} {region_code} {subregion_code} {province}
+//	{province_code} {county} {county_code} {city} {feature_id}
+//
+// Empty fields are skipped along with the text separating them from the
+// previous placeholder, so the example above gives "Paris, France" if the
+// province is empty and "Île-de-France, France" if the city is. Text before the
+// first placeholder and after the last one is always kept, unless all of the
+// fields are empty, in which case Format returns "". Anything in braces that
+// isn't a placeholder is left as it is.
+func (l Location) Format(template string) string {
+	prefix, name, rest := nextPlaceholder(template)
+	if name == "" {
+		return template
+	}
+
+	var (
+		out strings.Builder
+		sep string
+	)
+
+	for name != "" {
+		if v := formatFields[name](l); v != "" {
+			if out.Len() > 0 {
+				out.WriteString(sep)
+			}
+			out.WriteString(v)
+		}
+
+		// After the last placeholder sep is the text at the end of template.
+		sep, name, rest = nextPlaceholder(rest)
+	}
+
+	if out.Len() == 0 {
+		return ""
+	}
+
+	return prefix + out.String() + sep
+}
+
+// nextPlaceholder splits s into the text before the first placeholder, the
+// name of the placeholder and the rest of s. name is empty if there are no
+// placeholders in s.
+func nextPlaceholder(s string) (lit, name, rest string) {
+	for i := 0; i < len(s); i++ {
+		if s[i] != '{' {
+			continue
+		}
+
+		end := strings.IndexByte(s[i:], '}')
+		if end < 0 {
+			break
+		}
+
+		if n := s[i+1 : i+end]; formatFields[n] != nil {
+			return s[:i], n, s[i+end+1:]
+		}
+	}
+
+	return s, "", ""
+}
+
+// MostSpecific returns the finest administrative name in the Location and its
+// level, which is the JSON name of the field it came from. The precedence is
+// City > County > Province > Country, with CountryLong used as the country if
+// Country is empty, as in String. Both are empty if none of them are set.
+//
+// This gives a single label for a point, e.g. "Paris" at level "city", or
+// "France" at level "country" for a point outside of any city.
+func (l Location) MostSpecific() (level, name string) {
+	switch {
+	case l.City != "":
+		return "city", l.City
+	case l.County != "":
+		return "county", l.County
+	case l.Province != "":
+		return "province", l.Province
+	case l.Country != "":
+		return "country", l.Country
+	case l.CountryLong != "":
+		return "country", l.CountryLong
+	}
+
+	return "", ""
+}
+
+// CodePath returns the codes of the Location joined with slashes, from the
+// country down, e.g. "USA/US-WA/USA-53033" for Seattle, for use in logs or as
+// a key. It's made of
+//
+//	country code / province code / county code
+//
+// where the country code is CountryCode3, or CountryCode2 or
+// CountryCodeNumeric if it doesn't have that, the province code is
+// ProvinceCode and the county code is CountyCode. Empty codes, and the "-99"
+// Natural Earth uses for a missing code, are skipped along with their slash,
+// so a Location with just a country gives "FRA", and one with no codes gives
+// "". Cities don't have codes so they aren't included.
+func (l Location) CodePath() string {
+	country := firstValidCode(l.CountryCode3, l.CountryCode2, l.CountryCodeNumeric)
+
+	var path []string
+	for _, code := range []string{country, l.ProvinceCode, l.CountyCode} {
+		if code = firstValidCode(code); code != "" {
+			path = append(path, code)
+		}
+	}
+
+	return strings.Join(path, "/")
+}
+
+// firstValidCode returns the first of codes which isn't empty or "-99".
+func firstValidCode(codes ...string) string {
+	for _, c := range codes {
+		if c != "" && c != "-99" {
+			return c
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import "testing"
+
+func TestLocationFormat(t *testing.T) {
+	paris := Location{
+		Country:      "France",
+		CountryCode3: "FRA",
+		Province:     "Île-de-France",
+		City:         "Paris",
+	}
+
+	tests := []struct {
+		name     string
+		loc      Location
+		template string
+		expected string
+	}{
+		{
+			name:     "All fields",
+			loc:      paris,
+			template: "{city}, {province}, {country}",
+			expected: "Paris, Île-de-France, France",
+		},
+		{
+			name:     "Empty middle",
+			loc:      Location{Country: "France", City: "Paris"},
+			template: "{city}, {province}, {country}",
+			expected: "Paris, France",
+		},
+		{
+			name:     "Empty first",
+			loc:      Location{Country: "France", Province: "Île-de-France"},
+			template: "{city}, {province}, {country}",
+			expected: "Île-de-France, France",
+		},
+		{
+			name:     "Empty last",
+			loc:      Location{Province: "Île-de-France", City: "Paris"},
+			template: "{city}, {province}, {country}",
+			expected: "Paris, Île-de-France",
+		},
+		{
+			name:     "Prefix and suffix",
+			loc:      Location{Country: "France"},
+			template: "In {city} - {country}.",
+			expected: "In France.",
+		},
+		{
+			name:     "Nothing to format",
+			loc:      Location{},
+			template: "Near {city}, {country}.",
+			expected: "",
+		},
+		{
+			name:     "Unknown placeholder",
+			loc:      paris,
+			template: "{town} {city}",
+			expected: "{town} Paris",
+		},
+		{
+			name:     "No placeholders",
+			loc:      paris,
+			template: "somewhere",
+			expected: "somewhere",
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.loc.Format(test.template); got != test.expected {
+				t.Errorf("expected: %q\n got: %q\n", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestLocationMostSpecific(t *testing.T) {
+	tests := []struct {
+		name  string
+		loc   Location
+		level string
+		label string
+	}{
+		{
+			name:  "City",
+			loc:   Location{Country: "France", Province: "Île-de-France", City: "Paris"},
+			level: "city",
+			label: "Paris",
+		},
+		{
+			name:  "County",
+			loc:   Location{Country: "United States of America", Province: "Minnesota", County: "Stevens"},
+			level: "county",
+			label: "Stevens",
+		},
+		{
+			name:  "Province",
+			loc:   Location{Country: "France", Province: "Île-de-France"},
+			level: "province",
+			label: "Île-de-France",
+		},
+		{
+			name:  "Country",
+			loc:   Location{Country: "France", CountryCode3: "FRA"},
+			level: "country",
+			label: "France",
+		},
+		{
+			name:  "Country long",
+			loc:   Location{CountryLong: "French Republic"},
+			level: "country",
+			label: "French Republic",
+		},
+		{
+			name: "Empty",
+			loc:  Location{Continent: "Europe"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			level, label := test.loc.MostSpecific()
+			if level != test.level || label != test.label {
+				t.Errorf("expected: %q %q\n got: %q %q\n", test.level, test.label, level, label)
+			}
+		})
+	}
+}
+
+func TestLocationCodePath(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       Location
+		expected string
+	}{
+		{"Empty", Location{}, ""},
+		{"Country", Location{CountryCode2: "FR", CountryCode3: "FRA"}, "FRA"},
+		{"Province", Location{CountryCode3: "FRA", ProvinceCode: "FR-IDF", City: "Paris"}, "FRA/FR-IDF"},
+		{"County", Location{CountryCode3: "USA", ProvinceCode: "US-WA", CountyCode: "USA-53033"}, "USA/US-WA/USA-53033"},
+		{"SkipsProvince", Location{CountryCode3: "USA", CountyCode: "USA-53033"}, "USA/USA-53033"},
+		{"NoCountry", Location{ProvinceCode: "FR-IDF"}, "FR-IDF"},
+		{"Alpha2", Location{CountryCode2: "FR", CountryCode3: "-99"}, "FR"},
+		{"Numeric", Location{CountryCode2: "-99", CountryCode3: "-99", CountryCodeNumeric: "250", ProvinceCode: "FR-75"}, "250/FR-75"},
+		{"Missing", Location{CountryCode3: "-99", ProvinceCode: "-99"}, ""},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.in.CodePath(); got != test.expected {
+				t.Errorf("expected: %q, got: %q", test.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"fmt"
+	"io/fs"
+
+	"github.com/golang/geo/s2"
+)
+
+// NewFromFS returns an Rgeo struct built from GeoJSON FeatureCollection files
+// in fsys, such as an embed.FS. Each name is a pattern as used by fs.Glob, so
+// "data/*.geojson" loads every matching file, and each pattern has to match at
+// least one file. Files can be plain GeoJSON, gzipped, or compressed with a
+// codec added with RegisterDecompressor, which is detected from their
+// contents. The features go through the same conversion as in New.
+//
+// Each file is its own dataset, named by its path in fsys, for
+// ReverseGeocodeWithGeometry and DatasetNames. A file matched by more than one
+// pattern is only loaded once.
+func NewFromFS(fsys fs.FS, names ...string) (*Rgeo, error) {
+	ret := newRgeo()
+	loaded := make(map[string]bool)
+
+	for _, pattern := range names {
+		matches, err := fs.Glob(fsys, pattern)
+		if err != nil {
+			return nil, fmt.Errorf("bad pattern %q: %w", pattern, err)
+		}
+		if len(matches) == 0 {
+			return nil, fmt.Errorf("no files match %q", pattern)
+		}
+
+		for _, name := range matches {
+			if loaded[name] {
+				continue
+			}
+			loaded[name] = true
+
+			b, err := fs.ReadFile(fsys, name)
+			if err != nil {
+				return nil, err
+			}
+
+			_, compressed := decompressorFor(b)
+			fc, err := decodeDataset(b, compressed, fmt.Sprintf("dataset %q", name))
+			if err != nil {
+				return nil, err
+			}
+
+			if err := ret.addFeatureCollection(fc, name); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	ret.query = s2.NewContainsPointQuery(ret.index, s2.VertexModelOpen)
+
+	return ret, nil
+}
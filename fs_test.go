@@ -0,0 +1,68 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+func TestNewFromFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"data/squares.geojson":  {Data: []byte(twoSquaresGeo)},
+		"data/square.json.gz":   {Data: compressData(t, squareGeo)},
+		"data/empty.geojson":    {Data: nil},
+		"other/squares.geojson": {Data: []byte(twoSquaresGeo)},
+	}
+
+	r, err := NewFromFS(fsys, "data/*.gz", "data/squares.geojson", "data/square*")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := deep.Equal([]string{"data/square.json.gz", "data/squares.geojson"}, r.DatasetNames()); diff != nil {
+		t.Error(diff)
+	}
+
+	if l, err := r.ReverseGeocode(orb.Point{2.5, 0.5}); err != nil || l.CountryCode3 != "EST" {
+		t.Errorf("expected EST, got: %v, %v", l, err)
+	}
+	if _, err := r.ReverseGeocode(orb.Point{0.5, 52.5}); err != nil {
+		t.Error(err)
+	}
+
+	errs := []struct {
+		name    string
+		pattern string
+		err     string
+	}{
+		{"No match", "data/*.wkb", `no files match "data/*.wkb"`},
+		{"Bad pattern", "data/[", `bad pattern "data/[": syntax error in pattern`},
+		{"Empty file", "data/empty.geojson", `no data in dataset "data/empty.geojson"`},
+	}
+	for _, test := range errs {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewFromFS(fsys, test.pattern)
+			if err == nil || err.Error() != test.err {
+				t.Errorf("expected error: %s\n got: %v\n", test.err, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"testing"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/geojson"
+)
+
+// FuzzPolygonFromGeometry checks that converting GeoJSON geometries to s2
+// polygons doesn't panic, and that geometries with valid coordinates give
+// loops s2 accepts. Run it with
+//
+//	go test -fuzz FuzzPolygonFromGeometry
+func FuzzPolygonFromGeometry(f *testing.F) {
+	seeds := []string{
+		`{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}`,
+		`{"type":"Polygon","coordinates":[[[0,0],[0,1],[1,1],[1,0],[0,0]]]}`,
+		`{"type":"Polygon","coordinates":[[[0,0],[4,0],[4,4],[0,4],[0,0]],[[1,1],[1,3],[3,3],[3,1],[1,1]]]}`,
+		`{"type":"MultiPolygon","coordinates":[[[[0,0],[1,0],[1,1],[0,1],[0,0]]],[[[2,0],[3,0],[3,1],[2,1],[2,0]]]]}`,
+		`{"type":"Polygon","coordinates":[[[179,0],[-179,0],[-179,1],[179,1],[179,0]]]}`,
+		`{"type":"Polygon","coordinates":[[[0,89],[120,89],[-120,89],[0,89]]]}`,
+		`{"type":"Polygon","coordinates":[[[0,0],[1,1],[2,2],[0,0]]]}`,
+		`{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,0],[1,1],[0,0],[0,0]]]}`,
+		`{"type":"Point","coordinates":[0,0]}`,
+	}
+	for _, s := range seeds {
+		f.Add([]byte(s))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		g, err := geojson.UnmarshalGeometry(data)
+		if err != nil || g.Geometry() == nil {
+			return
+		}
+
+		p, err := polygonFromGeometry(g.Geometry(), options{})
+		if err != nil || !validCoords(g.Geometry()) {
+			return
+		}
+
+		for i, l := range p.Loops() {
+			if err := l.Validate(); err != nil {
+				t.Errorf("invalid loop %d from %s: %s", i, data, err)
+			}
+		}
+	})
+}
+
+// validCoords reports whether all of the coordinates in g are valid longitudes
+// and latitudes.
+func validCoords(g orb.Geometry) bool {
+	var mp orb.MultiPolygon
+	switch g := g.(type) {
+	case orb.Polygon:
+		mp = orb.MultiPolygon{g}
+	case orb.MultiPolygon:
+		mp = g
+	}
+
+	for _, p := range mp {
+		for _, r := range p {
+			for _, c := range r {
+				if !(c[0] >= -180 && c[0] <= 180 && c[1] >= -90 && c[1] <= 90) {
+					return false
+				}
+			}
+		}
+	}
+
+	return true
+}
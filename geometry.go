@@ -0,0 +1,71 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"fmt"
+	"sort"
+)
+
+// WithGeometryDatasets only keeps the GeoJSON geometry of the given datasets
+// passed to NewWithOptions, e.g. keeping Countries10 for drawing but dropping
+// Cities10. The geometry is what ReverseGeocodeWithGeometry, GetGeometry,
+// ReverseGeocodeFeature and ExportGeoJSONTo return, and it's held on top of
+// the s2 shapes used for everything else, so dropping it for datasets where
+// you don't need it saves a lot of memory. Those methods give an error for the
+// other datasets, and the rest work the same for all of them.
+//
+// If it's used more than once the datasets from all of them are kept. By
+// default the geometry of every dataset is kept.
+func WithGeometryDatasets(datasets ...func() []byte) Option {
+	return func(o *options) {
+		if o.geometryDatasets == nil {
+			o.geometryDatasets = make(map[string]bool)
+		}
+
+		for _, d := range datasets {
+			o.geometryDatasets[getFunctionName(d)] = true
+		}
+	}
+}
+
+// GeometryDatasets returns the sorted names of the loaded datasets which have
+// their geometry kept, i.e. the ones which can be used with GetGeometry and
+// ReverseGeocodeWithGeometry. It's the same as DatasetNames unless
+// WithGeometryDatasets is used.
+func (r *Rgeo) GeometryDatasets() []string {
+	names := make([]string, 0, len(r.datasets))
+	for _, name := range r.datasets {
+		if r.keepsGeometry(name) {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// keepsGeometry reports whether the geometry of the named dataset is kept.
+func (r *Rgeo) keepsGeometry(dataset string) bool {
+	return r.opts.geometryDatasets == nil || r.opts.geometryDatasets[dataset]
+}
+
+// errNoGeometry returns the error for a dataset which doesn't have its geometry
+// kept.
+func (r *Rgeo) errNoGeometry(dataset string) error {
+	return fmt.Errorf("geometry not kept for dataset %q (have geometry for %v)", dataset, r.GeometryDatasets())
+}
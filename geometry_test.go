@@ -0,0 +1,73 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"io"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+func TestWithGeometryDatasets(t *testing.T) {
+	kept := func() []byte { return compressData(t, squareGeo) }
+	dropped := func() []byte { return compressData(t, twoSquaresGeo) }
+
+	r, err := NewWithOptions([]func() []byte{kept, dropped}, WithGeometryDatasets(kept))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	keptName, droppedName := getFunctionName(kept), getFunctionName(dropped)
+
+	if diff := deep.Equal([]string{keptName}, r.GeometryDatasets()); diff != nil {
+		t.Error(diff)
+	}
+	if len(r.DatasetNames()) != 2 {
+		t.Errorf("expected both datasets to be listed, got: %v", r.DatasetNames())
+	}
+
+	if g, err := r.GetGeometry(orb.Point{0.5, 52.5}, keptName); err != nil || g == nil {
+		t.Errorf("expected geometry, got: %v, %v", g, err)
+	}
+
+	if _, err := r.GetGeometry(orb.Point{0.5, 0.5}, droppedName); err == nil {
+		t.Error("expected error for dataset without geometry")
+	}
+	if _, err := r.ReverseGeocodeWithGeometry(orb.Point{0.5, 0.5}, droppedName); err == nil {
+		t.Error("expected error for dataset without geometry")
+	}
+	if err := r.ExportGeoJSONTo(io.Discard, droppedName); err == nil {
+		t.Error("expected error for dataset without geometry")
+	}
+
+	// Everything else still knows which dataset the shapes are from.
+	if diff := deep.Equal([]string{droppedName}, r.DatasetsContaining(orb.Point{0.5, 0.5})); diff != nil {
+		t.Error(diff)
+	}
+	if _, err := r.Perimeter(orb.Point{0.5, 0.5}, droppedName); err != nil {
+		t.Error(err)
+	}
+
+	all, err := New(kept, dropped)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(all.DatasetNames(), all.GeometryDatasets()); diff != nil {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"math"
+
+	"github.com/paulmach/orb"
+)
+
+// SnapToGrid rounds both coordinates of loc to the given number of decimal
+// places, so that nearby points give the same value. This is meant for use as
+// a cache key in front of ReverseGeocode.
+//
+// The size of a grid cell at the equator for each number of decimals is about:
+//
+//	0: 111 km  (country)
+//	1: 11 km   (large city)
+//	2: 1.1 km  (town or village)
+//	3: 110 m   (neighbourhood)
+//	4: 11 m    (street)
+//	5: 1.1 m   (tree)
+//
+// Cells get narrower in longitude towards the poles. Any point can be up to
+// half a cell away from its snapped value, so a cached result may be wrong for
+// points that close to a border. At 2 or more decimals this is well under the
+// resolution of the included datasets. Negative decimals round to tens,
+// hundreds etc. of degrees.
+func SnapToGrid(loc orb.Point, decimals int) orb.Point {
+	scale := math.Pow(10, float64(decimals))
+	return orb.Point{snap(loc[0], scale), snap(loc[1], scale)}
+}
+
+// snap rounds v to the nearest multiple of 1/scale.
+func snap(v, scale float64) float64 {
+	v = math.Round(v*scale) / scale
+	if v == 0 {
+		// Avoid -0, which prints differently to 0.
+		return 0
+	}
+
+	return v
+}
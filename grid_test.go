@@ -0,0 +1,47 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/paulmach/orb"
+)
+
+func TestSnapToGrid(t *testing.T) {
+	tests := []struct {
+		in       orb.Point
+		decimals int
+		expected string
+	}{
+		{orb.Point{141.354, 43.0621}, 2, "[141.35 43.06]"},
+		{orb.Point{141.356, 43.0679}, 2, "[141.36 43.07]"},
+		{orb.Point{-0.0004, 51.5045}, 3, "[0 51.505]"},
+		{orb.Point{-149.901785, 61.199134}, 0, "[-150 61]"},
+		{orb.Point{-149.901785, 61.199134}, -1, "[-150 60]"},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprint(test.in, test.decimals), func(t *testing.T) {
+			result := fmt.Sprint(SnapToGrid(test.in, test.decimals))
+			if result != test.expected {
+				t.Errorf("expected: %s, got: %s", test.expected, result)
+			}
+		})
+	}
+}
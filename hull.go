@@ -0,0 +1,69 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+// ConvexHull returns the convex hull of the shape from the given dataset which
+// contains the coordinate, a simple outline for rendering at small scales or
+// rough spatial checks. For MultiPolygons it's the hull of all of the
+// polygons together, so e.g. the hull of a country with islands covers the
+// sea between them. It returns ErrLocationNotFound if no shape contains the
+// coordinate.
+//
+// The hull is worked out on the sphere with s2.ConvexHullQuery, from the s2
+// shape rather than the GeoJSON geometry, so it works with datasets whose
+// geometry isn't kept. Its edges are great circles, so drawn as straight lines
+// on a map they can cut a little inside the shape, most of all for long edges
+// far from the equator. The ring is wound counter-clockwise and closed, as in
+// RFC 7946. Shapes which aren't in any hemisphere, like those around a pole,
+// have no convex hull and give an error.
+func (r *Rgeo) ConvexHull(loc orb.Point, dataset string) (orb.Polygon, error) {
+	shape, _, err := r.datasetShape(loc, dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	p, ok := shape.(*s2.Polygon)
+	if !ok {
+		return nil, ErrLocationNotFound
+	}
+
+	q := s2.NewConvexHullQuery()
+	q.AddPolygon(p)
+
+	hull := q.ConvexHull()
+	if hull.IsFull() {
+		return nil, errors.New("shape has no convex hull, it isn't in one hemisphere")
+	}
+	if hull.IsEmpty() {
+		return nil, ErrLocationNotFound
+	}
+
+	ring := make(orb.Ring, 0, hull.NumVertices()+1)
+	for _, v := range hull.Vertices() {
+		ll := s2.LatLngFromPoint(v)
+		ring = append(ring, orb.Point{ll.Lng.Degrees(), ll.Lat.Degrees()})
+	}
+	ring = append(ring, ring[0])
+
+	return orb.Polygon{ring}, nil
+}
@@ -0,0 +1,108 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/planar"
+)
+
+// hullGeo has an L shaped country and one made of two islands.
+const hullGeo = `{
+	"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"ADMIN":"L"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,0],[2,0],[2,1],[1,1],[1,2],[0,2],[0,0]]]}},
+			{"type":"Feature",
+			"properties":{"ADMIN":"Islands"},
+			"geometry":{"type":"MultiPolygon",
+				"coordinates":[[[[4,10],[5,10],[5,11],[4,11],[4,10]]],
+					[[[6,10],[7,10],[7,11],[6,11],[6,10]]]]}}
+		]
+	}`
+
+func TestConvexHull(t *testing.T) {
+	dataset := func() []byte { return compressData(t, hullGeo) }
+
+	r, err := New(dataset)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		in       orb.Point
+		vertices int
+		inside   orb.Point
+	}{
+		// The corner of the L at (1, 1) isn't on the hull, and the hull covers
+		// the space inside the corner.
+		{name: "L", in: orb.Point{0.5, 0.5}, vertices: 5, inside: orb.Point{1.4, 1.4}},
+		// The hull of both islands covers the sea between them. Its edges are
+		// great circles, which bend away from the equator, so the southern
+		// corners between the islands are on it but the northern ones aren't.
+		{name: "Islands", in: orb.Point{4.5, 10.5}, vertices: 6, inside: orb.Point{5.5, 10.5}},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			hull, err := r.ConvexHull(test.in, getFunctionName(dataset))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if len(hull) != 1 {
+				t.Fatalf("expected 1 ring, got %d", len(hull))
+			}
+			ring := hull[0]
+			if !ring.Closed() {
+				t.Error("expected a closed ring")
+			}
+			if n := len(ring) - 1; n != test.vertices {
+				t.Errorf("expected %d vertices, got %d: %v", test.vertices, n, ring)
+			}
+			if ring.Orientation() != orb.CCW {
+				t.Error("expected a counter-clockwise ring")
+			}
+
+			// Every vertex of the hull is one of the shape's, to within
+			// rounding.
+			for _, p := range ring {
+				if math.Abs(p[0]-math.Round(p[0])) > 1e-9 || math.Abs(p[1]-math.Round(p[1])) > 1e-9 {
+					t.Errorf("unexpected vertex %v", p)
+				}
+			}
+
+			if !planar.PolygonContains(hull, test.inside) {
+				t.Errorf("expected the hull to contain %v", test.inside)
+			}
+		})
+	}
+
+	if _, err := r.ConvexHull(orb.Point{10, 10}, getFunctionName(dataset)); !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("expected error: %v\n got: %v\n", ErrLocationNotFound, err)
+	}
+	if _, err := r.ConvexHull(orb.Point{0.5, 0.5}, "missing"); err == nil {
+		t.Error("expected error for missing dataset")
+	}
+}
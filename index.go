@@ -0,0 +1,60 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"fmt"
+
+	"github.com/golang/geo/s2"
+)
+
+// ShapeIndex returns the s2.ShapeIndex holding all of the loaded shapes, so you
+// can run your own s2 queries (edge queries, region coverings, etc.) against
+// them. Use ShapeLocation to get the Location of any shapes found this way.
+//
+// The index is shared with the Rgeo and must be treated as read only. Adding
+// or removing shapes directly will corrupt the results of every other method.
+//
+// The index is built with s2's defaults, which split cells until they hold at
+// most 10 edges. Unlike the C++ library, golang/geo doesn't have a way to
+// change this, so it can't be tuned from rgeo either.
+func (r *Rgeo) ShapeIndex() *s2.ShapeIndex {
+	return r.index
+}
+
+// DatasetShapes returns the shapes loaded from the given dataset, in the order
+// of the features in its GeoJSON, followed by any added to it with AddFeature.
+// Features that weren't loaded, because of WithFeatureFilter or DedupCountries
+// or because every ring had no area, are left out without changing the order
+// of the rest. So the order is the same every time the same data is loaded,
+// for iterating over a dataset with ShapeLocation, GetGeometry, etc.
+//
+// The slice is a copy, but the shapes are shared with the Rgeo and must not be
+// modified.
+func (r *Rgeo) DatasetShapes(dataset string) ([]s2.Shape, error) {
+	if _, ok := r.geoms[dataset]; !ok {
+		return nil, fmt.Errorf("dataset not found: %q (have %v)", dataset, r.DatasetNames())
+	}
+
+	return append([]s2.Shape(nil), r.order[dataset]...), nil
+}
+
+// ShapeLocation returns the Location of a shape from the index returned by
+// ShapeIndex, ok is false if the shape isn't one of the loaded shapes.
+func (r *Rgeo) ShapeLocation(shape s2.Shape) (loc Location, ok bool) {
+	loc, ok = r.locs[shape]
+	return
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+func TestShapeIndex(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, twoSquaresGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	index := r.ShapeIndex()
+	if n := index.Len(); n != 2 {
+		t.Fatalf("expected 2 shapes, got %d", n)
+	}
+
+	// Find the shape closest to a point between the two squares, but nearer
+	// the east one.
+	q := s2.NewClosestEdgeQuery(index, s2.NewClosestEdgeQueryOptions().MaxResults(1))
+	res := q.FindEdges(s2.NewMinDistanceToPointTarget(s2.PointFromLatLng(s2.LatLngFromDegrees(0.5, 1.8))))
+	if len(res) != 1 {
+		t.Fatalf("expected 1 result, got %d", len(res))
+	}
+
+	loc, ok := r.ShapeLocation(index.Shape(res[0].ShapeID()))
+	if !ok {
+		t.Fatal("shape not found")
+	}
+	if diff := deep.Equal(Location{Country: "East", CountryCode3: "EST"}, loc); diff != nil {
+		t.Error(diff)
+	}
+
+	if _, ok := r.ShapeLocation(s2.PolygonFromLoops(nil)); ok {
+		t.Error("expected unknown shape not to be found")
+	}
+}
+
+func TestDatasetShapes(t *testing.T) {
+	squares := func() []byte { return compressData(t, twoSquaresGeo) }
+	square := func() []byte { return compressData(t, squareGeo) }
+
+	r, err := New(square, squares)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	added := orb.Polygon{{{4, 0}, {5, 0}, {5, 1}, {4, 1}, {4, 0}}}
+	if err := r.AddFeature(added, Location{Country: "Added"}, getFunctionName(squares)); err != nil {
+		t.Fatal(err)
+	}
+
+	shapes, err := r.DatasetShapes(getFunctionName(squares))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, shape := range shapes {
+		loc, ok := r.ShapeLocation(shape)
+		if !ok {
+			t.Fatal("shape not found")
+		}
+		got = append(got, loc.Country)
+	}
+	if diff := deep.Equal([]string{"West", "East", "Added"}, got); diff != nil {
+		t.Error(diff)
+	}
+
+	// A dataset with nothing loaded has no shapes, but isn't an error.
+	none := WithFeatureFilter(func(map[string]interface{}) bool { return false })
+	empty, err := NewWithOptions([]func() []byte{squares}, none)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if shapes, err := empty.DatasetShapes(getFunctionName(squares)); err != nil || len(shapes) != 0 {
+		t.Errorf("expected no shapes, got: %v, %v", shapes, err)
+	}
+
+	if _, err := r.DatasetShapes("other"); err == nil {
+		t.Error("expected error for unknown dataset")
+	}
+}
@@ -0,0 +1,308 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/encoding/wkb"
+)
+
+// indexMagic is the header every file written by WriteIndex starts with, it's
+// followed by the format version as a big endian uint16.
+var indexMagic = []byte("rgeoidx\x00")
+
+// indexVersion is the version of the format written by WriteIndex, it has to
+// be changed whenever indexFile or anything in it changes.
+const indexVersion uint16 = 2
+
+// ErrIndexVersion is returned by ReadIndex for an index written in a different
+// version of the format, which has to be written again from the datasets.
+var ErrIndexVersion = errors.New("unsupported index version")
+
+// indexFile is everything written by WriteIndex after the header.
+type indexFile struct {
+	Datasets []indexDataset
+	Shapes   []indexShape
+}
+
+// indexDataset is a dataset in an indexFile. Shapes are the positions in
+// indexFile.Shapes of the shapes of the dataset, in the order of its features.
+type indexDataset struct {
+	Name     string
+	Level    AdminLevel
+	HasLevel bool
+	Geometry bool
+	Shapes   []int
+}
+
+// indexShape is a shape in an indexFile, in the order they were added to the
+// index. The pointers are only set for shapes which had a value.
+type indexShape struct {
+	Polygon    []byte
+	Location   Location
+	Geometry   []byte
+	CityPop    *float64
+	Area       *float64
+	Priority   *int
+	ValidFrom  time.Time
+	ValidTo    time.Time
+	HasValid   bool
+	MinAlt     float64
+	MaxAlt     float64
+	HasAlt     bool
+	Properties []byte
+}
+
+// WriteIndex writes the s2 shapes of r, along with their Locations and
+// everything else kept about them, so that ReadIndex can load them again
+// without decoding the GeoJSON. This is much faster than New for servers which
+// start often with the same datasets.
+//
+// The GeoJSON geometry is only written for the datasets that keep it, so load
+// r with WithGeometryDatasets to leave it out and make the index smaller. The
+// caches of things like NearestCity and Neighbors aren't written, they're
+// worked out again when they're first used.
+//
+// The format is versioned, and ReadIndex rejects an index from a different
+// version with ErrIndexVersion, so it should be written again from the
+// datasets after upgrading rgeo.
+func (r *Rgeo) WriteIndex(w io.Writer) error {
+	pos := make(map[s2.Shape]int, r.index.Len())
+
+	f := indexFile{Shapes: make([]indexShape, r.index.Len())}
+	for i := range f.Shapes {
+		shape := r.index.Shape(int32(i))
+		pos[shape] = i
+
+		s, err := r.indexShape(shape)
+		if err != nil {
+			return fmt.Errorf("shape %d: %w", i, err)
+		}
+		f.Shapes[i] = s
+	}
+
+	for _, name := range r.datasets {
+		ds := indexDataset{Name: name, Geometry: r.keepsGeometry(name)}
+		ds.Level, ds.HasLevel = r.levels[name]
+
+		for _, shape := range r.order[name] {
+			i, ok := pos[shape]
+			if !ok {
+				return fmt.Errorf("dataset %q has a shape that isn't in the index", name)
+			}
+			ds.Shapes = append(ds.Shapes, i)
+
+			if !ds.Geometry || r.geoms[name][shape] == nil {
+				continue
+			}
+
+			b, err := wkb.Marshal(r.geoms[name][shape])
+			if err != nil {
+				return fmt.Errorf("dataset %q: geometry of shape %d: %w", name, i, err)
+			}
+			f.Shapes[i].Geometry = b
+		}
+
+		f.Datasets = append(f.Datasets, ds)
+	}
+
+	header := make([]byte, len(indexMagic)+2)
+	copy(header, indexMagic)
+	binary.BigEndian.PutUint16(header[len(indexMagic):], indexVersion)
+
+	if _, err := w.Write(header); err != nil {
+		return fmt.Errorf("failed to write index header: %w", err)
+	}
+
+	if err := gob.NewEncoder(w).Encode(&f); err != nil {
+		return fmt.Errorf("failed to write index: %w", err)
+	}
+
+	return nil
+}
+
+// indexShape returns everything kept about shape for WriteIndex, apart from
+// its geometry.
+func (r *Rgeo) indexShape(shape s2.Shape) (indexShape, error) {
+	p, ok := shape.(*s2.Polygon)
+	if !ok {
+		return indexShape{}, fmt.Errorf("needs *s2.Polygon, got %T", shape)
+	}
+
+	var buf bytes.Buffer
+	if err := p.Encode(&buf); err != nil {
+		return indexShape{}, fmt.Errorf("failed to encode polygon: %w", err)
+	}
+
+	s := indexShape{Polygon: buf.Bytes(), Location: r.locs[shape]}
+
+	if v, ok := r.cityPops[shape]; ok {
+		s.CityPop = &v
+	}
+	if v, ok := r.areas[shape]; ok {
+		s.Area = &v
+	}
+	if v, ok := r.priorities[shape]; ok {
+		s.Priority = &v
+	}
+	if v, ok := r.validity[shape]; ok {
+		s.ValidFrom, s.ValidTo, s.HasValid = v.from, v.to, true
+	}
+	if v, ok := r.altitudes[shape]; ok {
+		s.MinAlt, s.MaxAlt, s.HasAlt = v.min, v.max, true
+	}
+	if v, ok := r.props[shape]; ok {
+		b, err := json.Marshal(v)
+		if err != nil {
+			return indexShape{}, fmt.Errorf("failed to encode properties: %w", err)
+		}
+		s.Properties = b
+	}
+
+	return s, nil
+}
+
+// ReadIndex returns an Rgeo loaded from an index written by WriteIndex. The
+// shapes, Locations and dataset options are the ones from the Rgeo that wrote
+// it, so options for loading, like WithFeatureFilter or WithDatasetPriority,
+// have no effect here, but the options for queries, like WithShapePreference
+// and WithCityPreference, are used as with NewWithOptions. The properties for
+// ReverseGeocodeProperties are there if the Rgeo that wrote it had them,
+// without WithProperties.
+//
+// An index written by a different version of rgeo's format gives an error
+// wrapping ErrIndexVersion.
+func ReadIndex(rd io.Reader, opts ...Option) (*Rgeo, error) {
+	header := make([]byte, len(indexMagic)+2)
+	if _, err := io.ReadFull(rd, header); err != nil {
+		return nil, fmt.Errorf("failed to read index header: %w", err)
+	}
+	if !bytes.Equal(header[:len(indexMagic)], indexMagic) {
+		return nil, errors.New("not an rgeo index")
+	}
+	if v := binary.BigEndian.Uint16(header[len(indexMagic):]); v != indexVersion {
+		return nil, fmt.Errorf("%w %d, this version of rgeo reads version %d", ErrIndexVersion, v, indexVersion)
+	}
+
+	var f indexFile
+	if err := gob.NewDecoder(rd).Decode(&f); err != nil {
+		return nil, fmt.Errorf("failed to read index: %w", err)
+	}
+
+	ret := newRgeo(opts...)
+	strs := make(interner)
+
+	shapes := make([]s2.Shape, len(f.Shapes))
+	for i, s := range f.Shapes {
+		shape, err := ret.addIndexShape(s, strs)
+		if err != nil {
+			return nil, fmt.Errorf("shape %d: %w", i, err)
+		}
+		shapes[i] = shape
+
+		if s.Properties != nil {
+			ret.opts.properties = true
+		}
+	}
+
+	kept := make(map[string]bool)
+	for _, ds := range f.Datasets {
+		ds.Geometry = ds.Geometry && ret.keepsGeometry(ds.Name)
+		if ds.Geometry {
+			kept[ds.Name] = true
+		}
+
+		shpGeoms := ret.datasetGeoms(ds.Name, len(ds.Shapes))
+		for _, i := range ds.Shapes {
+			if i < 0 || i >= len(shapes) {
+				return nil, fmt.Errorf("dataset %q: shape %d out of range", ds.Name, i)
+			}
+
+			var geom orb.Geometry
+			if b := f.Shapes[i].Geometry; ds.Geometry && len(b) > 0 {
+				g, err := wkb.Unmarshal(b)
+				if err != nil {
+					return nil, fmt.Errorf("dataset %q: geometry of shape %d: %w", ds.Name, i, err)
+				}
+				geom = g
+			}
+
+			shpGeoms[shapes[i]] = geom
+			ret.order[ds.Name] = append(ret.order[ds.Name], shapes[i])
+		}
+
+		if ds.HasLevel {
+			ret.levels[ds.Name] = ds.Level
+		}
+	}
+
+	if len(kept) < len(f.Datasets) {
+		ret.opts.geometryDatasets = kept
+	}
+
+	ret.query = s2.NewContainsPointQuery(ret.index, s2.VertexModelOpen)
+
+	return ret, nil
+}
+
+// addIndexShape decodes a shape from an index and adds it to r, with
+// everything kept about it.
+func (r *Rgeo) addIndexShape(s indexShape, strs interner) (s2.Shape, error) {
+	p := new(s2.Polygon)
+	if err := p.Decode(bytes.NewReader(s.Polygon)); err != nil {
+		return nil, fmt.Errorf("failed to decode polygon: %w", err)
+	}
+
+	r.index.Add(p)
+	r.locs[p] = strs.location(s.Location)
+
+	if s.CityPop != nil {
+		r.cityPops[p] = *s.CityPop
+	}
+	if s.Area != nil {
+		r.areas[p] = *s.Area
+	} else if r.opts.shapePreference != ShapeFirst {
+		r.areas[p] = p.Area()
+	}
+	if s.Priority != nil {
+		r.priorities[p] = *s.Priority
+	}
+	if s.HasValid {
+		r.validity[p] = validity{from: s.ValidFrom, to: s.ValidTo}
+	}
+	if s.HasAlt {
+		r.altitudes[p] = altitudeRange{min: s.MinAlt, max: s.MaxAlt}
+	}
+	if s.Properties != nil {
+		var props map[string]interface{}
+		if err := json.Unmarshal(s.Properties, &props); err != nil {
+			return nil, fmt.Errorf("failed to decode properties: %w", err)
+		}
+		r.props[p] = props
+	}
+
+	return p, nil
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+func TestWriteIndex(t *testing.T) {
+	squares := func() []byte { return compressData(t, twoSquaresGeo) }
+	levels := func() []byte { return compressData(t, levelsGeo) }
+
+	r, err := NewWithOptions([]func() []byte{squares, levels},
+		WithGeometryDatasets(squares), WithDatasetPriority(levels, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := r.WriteIndex(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadIndex(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := deep.Equal(r.DatasetNames(), got.DatasetNames()); diff != nil {
+		t.Error(diff)
+	}
+	if diff := deep.Equal(r.GeometryDatasets(), got.GeometryDatasets()); diff != nil {
+		t.Error(diff)
+	}
+	if diff := deep.Equal(r.Capabilities(), got.Capabilities()); diff != nil {
+		t.Error(diff)
+	}
+
+	for _, p := range []orb.Point{{0.5, 0.5}, {2.5, 0.5}, {1.5, 3.5}, {3.5, 2.5}, {10, 10}} {
+		want, wantErr := r.ReverseGeocode(p)
+		loc, err := got.ReverseGeocode(p)
+		if !errors.Is(err, wantErr) {
+			t.Errorf("%v: expected error %v, got %v", p, wantErr, err)
+		}
+		if diff := deep.Equal(want, loc); diff != nil {
+			t.Errorf("%v: %v", p, diff)
+		}
+	}
+
+	for _, name := range r.DatasetNames() {
+		want, _ := r.DatasetShapes(name)
+		shapes, err := got.DatasetShapes(name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(shapes) != len(want) {
+			t.Fatalf("%s: expected %d shapes, got %d", name, len(want), len(shapes))
+		}
+		for i := range shapes {
+			if diff := deep.Equal(r.locs[want[i]], got.locs[shapes[i]]); diff != nil {
+				t.Errorf("%s shape %d: %v", name, i, diff)
+			}
+		}
+	}
+
+	geom, err := got.GetGeometry(orb.Point{2.5, 0.5}, getFunctionName(squares))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(orb.Geometry(orb.Polygon{{{2, 0}, {3, 0}, {3, 1}, {2, 1}, {2, 0}}}), geom); diff != nil {
+		t.Error(diff)
+	}
+
+	if _, err := got.GetGeometry(orb.Point{1.5, 3.5}, getFunctionName(levels)); err == nil {
+		t.Error("expected error for dataset without geometry")
+	}
+}
+
+func TestWriteIndex_Properties(t *testing.T) {
+	r, err := NewWithOptions([]func() []byte{func() []byte { return compressData(t, twoSquaresGeo) }},
+		WithProperties())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := r.WriteIndex(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := ReadIndex(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	props, err := got.ReverseGeocodeProperties(orb.Point{2.5, 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(map[string]interface{}{"ADMIN": "East", "ISO_A3": "EST"}, props); diff != nil {
+		t.Error(diff)
+	}
+
+	// An index written without the properties doesn't have them.
+	plain, err := New(func() []byte { return compressData(t, twoSquaresGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	buf.Reset()
+	if err := plain.WriteIndex(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err = ReadIndex(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := got.ReverseGeocodeProperties(orb.Point{2.5, 0.5}); !errors.Is(err, ErrNoProperties) {
+		t.Errorf("expected ErrNoProperties, got %v", err)
+	}
+}
+
+func TestReadIndex_Invalid(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, squareGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var buf bytes.Buffer
+	if err := r.WriteIndex(&buf); err != nil {
+		t.Fatal(err)
+	}
+
+	old := append([]byte(nil), buf.Bytes()...)
+	binary.BigEndian.PutUint16(old[len(indexMagic):], indexVersion+1)
+	if _, err := ReadIndex(bytes.NewReader(old)); !errors.Is(err, ErrIndexVersion) {
+		t.Errorf("expected ErrIndexVersion, got %v", err)
+	}
+
+	if _, err := ReadIndex(bytes.NewReader([]byte(`{"type":"FeatureCollection"}`))); err == nil {
+		t.Error("expected error for GeoJSON")
+	}
+
+	if _, err := ReadIndex(bytes.NewReader(buf.Bytes()[:buf.Len()/2])); err == nil {
+		t.Error("expected error for truncated index")
+	}
+}
@@ -0,0 +1,56 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+// interner deduplicates strings, so that equal strings share the same backing
+// array rather than each being a separate allocation.
+type interner map[string]string
+
+// intern returns the first string equal to s that was passed to intern.
+func (in interner) intern(s string) string {
+	if s == "" {
+		return s
+	}
+
+	if v, ok := in[s]; ok {
+		return v
+	}
+
+	in[s] = s
+
+	return s
+}
+
+// location interns the fields of l which are shared by lots of shapes, i.e.
+// everything about the country. Province, county and city names are mostly
+// unique so they're left alone.
+func (in interner) location(l Location) Location {
+	l.Country = in.intern(l.Country)
+	l.CountryLong = in.intern(l.CountryLong)
+	l.Sovereign = in.intern(l.Sovereign)
+	l.CountryCode2 = in.intern(l.CountryCode2)
+	l.CountryCode3 = in.intern(l.CountryCode3)
+	l.CountryCodeNumeric = in.intern(l.CountryCodeNumeric)
+	l.CallingCode = in.intern(l.CallingCode)
+	l.CurrencyCode = in.intern(l.CurrencyCode)
+	l.Continent = in.intern(l.Continent)
+	l.Region = in.intern(l.Region)
+	l.SubRegion = in.intern(l.SubRegion)
+	l.RegionCode = in.intern(l.RegionCode)
+	l.SubRegionCode = in.intern(l.SubRegionCode)
+
+	return l
+}
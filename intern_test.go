@@ -0,0 +1,73 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"reflect"
+	"testing"
+	"unsafe"
+)
+
+func TestInterner(t *testing.T) {
+	in := make(interner)
+
+	a := in.intern(string([]byte("Europe")))
+	b := in.intern(string([]byte("Europe")))
+
+	if a != b || stringData(a) != stringData(b) {
+		t.Error("expected equal strings to share backing storage")
+	}
+}
+
+// stringData returns the address of the backing array of s.
+func stringData(s string) uintptr {
+	return (*reflect.StringHeader)(unsafe.Pointer(&s)).Data
+}
+
+// TestIntern_Savings compares the bytes needed for the Location strings in
+// Provinces10 if each one is a separate allocation (as decoded from JSON) with
+// the bytes actually held after interning.
+func TestIntern_Savings(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping loading Provinces10 in short mode")
+	}
+
+	r, err := New(Provinces10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var total, held int
+	seen := make(map[uintptr]bool)
+	for _, loc := range r.locs {
+		v := reflect.ValueOf(loc)
+		for i := 0; i < v.NumField(); i++ {
+			s := v.Field(i).String()
+			total += len(s)
+
+			if p := stringData(s); s != "" && !seen[p] {
+				seen[p] = true
+				held += len(s)
+			}
+		}
+	}
+
+	t.Logf("Location strings in Provinces10: %d bytes uninterned, %d bytes interned", total, held)
+
+	if held > total*3/4 {
+		t.Errorf("expected interning to save at least a quarter of %d bytes, held %d", total, held)
+	}
+}
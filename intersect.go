@@ -0,0 +1,58 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"github.com/golang/geo/s2"
+)
+
+// intersectingShapes returns the shapes in the index which intersect p, in the
+// order they were added.
+func (r *Rgeo) intersectingShapes(p *s2.Polygon) []s2.Shape {
+	bound := p.RectBound()
+
+	var ret []s2.Shape
+	for i := int32(0); i < int32(r.index.Len()); i++ {
+		shape, ok := r.index.Shape(i).(*s2.Polygon)
+		if !ok || !bound.Intersects(shape.RectBound()) {
+			continue
+		}
+
+		if p.Intersects(shape) {
+			ret = append(ret, shape)
+		}
+	}
+
+	return ret
+}
+
+// uniqueLocations returns the Locations of the given shapes, without
+// duplicates, in the same order as the shapes.
+func (r *Rgeo) uniqueLocations(shapes []s2.Shape) []Location {
+	seen := make(map[Location]bool, len(shapes))
+	ret := make([]Location, 0, len(shapes))
+	for _, shape := range shapes {
+		loc := r.locs[shape]
+		if seen[loc] {
+			continue
+		}
+
+		seen[loc] = true
+		ret = append(ret, loc)
+	}
+
+	return ret
+}
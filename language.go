@@ -0,0 +1,84 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import "strings"
+
+// languages are the languages Natural Earth has names in, as the suffixes of
+// its NAME_ (admin-0) and name_ (admin-1) properties. English is left out, as
+// the usual names already are.
+var languages = []string{
+	"ar", "bn", "de", "el", "es", "fa", "fr", "he", "hi", "hu", "id",
+	"it", "ja", "ko", "nl", "pl", "pt", "ru", "sv", "tr", "uk", "ur", "vi",
+	"zh", "zht",
+}
+
+// WithLanguage makes the Country, Province and County of each Location the
+// names in the given language, from the NAME_XX and name_xx properties of the
+// Natural Earth data, e.g. WithLanguage("fr") gives "Allemagne" rather than
+// "Germany". The language is the ISO 639-1 code of one of: ar, bn, de, el, es,
+// fa, fr, he, hi, hu, id, it, ja, ko, nl, pl, pt, ru, sv, tr, uk, ur, vi, zh
+// (simplified Chinese) or zht (traditional Chinese), in either case.
+//
+// Where a feature doesn't have a name in the language, or for any other
+// language, the usual English name is used. The other fields, including
+// CountryLong, aren't translated, as Natural Earth only has them in English.
+// Neither is the Country of counties, as the admin-2 data only has the names
+// of the counties themselves. Only the names of features loaded by
+// NewWithOptions and friends are changed, not those given to AddFeature.
+func WithLanguage(lang string) Option {
+	return func(o *options) {
+		o.language = strings.ToLower(lang)
+	}
+}
+
+// localize returns l with its Country, Province or County set to the names in
+// lang from the GeoJSON properties p, where there are any.
+func localize(l Location, p map[string]interface{}, lang string) Location {
+	if lang == "" || !isLanguage(lang) {
+		return l
+	}
+
+	if isAdmin2(p) {
+		// The NAME_XX properties of admin-2 features are the names of the
+		// county rather than the country.
+		if l.County != "" {
+			l.County = firstNonEmpty(getPropertyString(p, "NAME_"+strings.ToUpper(lang)), l.County)
+		}
+
+		return l
+	}
+
+	if l.Country != "" {
+		l.Country = firstNonEmpty(getPropertyString(p, "NAME_"+strings.ToUpper(lang)), l.Country)
+	}
+	if l.Province != "" {
+		l.Province = firstNonEmpty(getPropertyString(p, "name_"+lang), l.Province)
+	}
+
+	return l
+}
+
+// isLanguage reports whether lang is one of the languages.
+func isLanguage(lang string) bool {
+	for _, l := range languages {
+		if l == lang {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+// languageGeo has a province with a French name in a country with French and
+// German names, a country without any translations and a county, whose NAME_FR
+// is the name of the county rather than its country.
+const languageGeo = `{
+	"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"ADMIN":"Germany","NAME_FR":"Allemagne","NAME_DE":"Deutschland",
+				"name":"Bavaria","name_fr":"Bavière","name_de":null},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}},
+			{"type":"Feature",
+			"properties":{"ADMIN":"Nowhere"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[2,0],[3,0],[3,1],[2,1],[2,0]]]}},
+			{"type":"Feature",
+			"properties":{"ADMIN":"United States of America","TYPE":"County",
+				"NAME":"Whatcom","NAME_FR":"Comté de Whatcom"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[4,0],[5,0],[5,1],[4,1],[4,0]]]}}
+		]
+	}`
+
+func TestWithLanguage(t *testing.T) {
+	dataset := []func() []byte{func() []byte { return compressData(t, languageGeo) }}
+
+	tests := []struct {
+		lang     string
+		in       orb.Point
+		expected Location
+	}{
+		{lang: "", in: orb.Point{0.5, 0.5}, expected: Location{Country: "Germany", Province: "Bavaria"}},
+		{lang: "fr", in: orb.Point{0.5, 0.5}, expected: Location{Country: "Allemagne", Province: "Bavière"}},
+		{lang: "FR", in: orb.Point{0.5, 0.5}, expected: Location{Country: "Allemagne", Province: "Bavière"}},
+		{lang: "de", in: orb.Point{0.5, 0.5}, expected: Location{Country: "Deutschland", Province: "Bavaria"}},
+		{lang: "en", in: orb.Point{0.5, 0.5}, expected: Location{Country: "Germany", Province: "Bavaria"}},
+		{lang: "xx", in: orb.Point{0.5, 0.5}, expected: Location{Country: "Germany", Province: "Bavaria"}},
+		{lang: "fr", in: orb.Point{2.5, 0.5}, expected: Location{Country: "Nowhere"}},
+		{lang: "fr", in: orb.Point{4.5, 0.5}, expected: Location{Country: "United States of America", County: "Comté de Whatcom"}},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.lang+test.expected.Country, func(t *testing.T) {
+			r, err := NewWithOptions(dataset, WithLanguage(test.lang))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			loc, err := r.ReverseGeocode(test.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := deep.Equal(test.expected, loc); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestWithLanguage_Countries110(t *testing.T) {
+	r, err := NewWithOptions([]func() []byte{Countries110}, WithLanguage("es"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loc, err := r.ReverseGeocode(orb.Point{10.4515, 51.1657})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loc.Country != "Alemania" {
+		t.Errorf("expected Alemania, got %q", loc.Country)
+	}
+	if loc.CountryCode3 != "DEU" {
+		t.Errorf("expected DEU, got %q", loc.CountryCode3)
+	}
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"fmt"
+
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+// AdminLevel is a level of administrative division, used with
+// ReverseGeocodeAtLevel. Each level includes the ones above it.
+type AdminLevel int
+
+const (
+	// LevelCountry is the country fields, Country through SubRegionCode.
+	LevelCountry AdminLevel = iota
+
+	// LevelProvince adds Province and ProvinceCode.
+	LevelProvince
+
+	// LevelCounty adds County and CountyCode.
+	LevelCounty
+
+	// LevelCity adds City.
+	LevelCity
+)
+
+// levelFields are the Location fields for each AdminLevel.
+var levelFields = [...]Field{
+	LevelCountry: FieldCountry | FieldCountryLong | FieldSovereign |
+		FieldCountryCode2 | FieldCountryCode3 | FieldCountryCodeNumeric |
+		FieldCallingCode | FieldCurrencyCode |
+		FieldContinent | FieldRegion | FieldSubRegion |
+		FieldRegionCode | FieldSubRegionCode,
+	LevelProvince: FieldProvince | FieldProvinceCode,
+	LevelCounty:   FieldCounty | FieldCountyCode,
+	LevelCity:     FieldCity,
+}
+
+// fieldsUpTo returns the Location fields of level and every level above it,
+// along with the FeatureID.
+func fieldsUpTo(level AdminLevel) Field {
+	f := FieldFeatureID
+	for l := LevelCountry; l <= level; l++ {
+		f |= levelFields[l]
+	}
+
+	return f
+}
+
+// ReverseGeocodeAtLevel works like ReverseGeocode, but only fills in the
+// Location fields down to the given level, whichever datasets are loaded. The
+// shapes of finer levels, like the cities in Cities10 for LevelProvince, are
+// ignored completely, so they can't fill in coarser fields either.
+//
+// If the coordinate isn't in any shape at the given level, either because it's
+// outside of them or no dataset with that level is loaded, the Location only
+// has the coarser fields that were found, e.g. just the country fields for
+// LevelProvince with only Countries10 loaded. Use Location.Fields to check for
+// this. ErrLocationNotFound is only returned if nothing at or above the level
+// contains the coordinate.
+func (r *Rgeo) ReverseGeocodeAtLevel(loc orb.Point, level AdminLevel) (Location, error) {
+	if level < LevelCountry || level > LevelCity {
+		return Location{}, fmt.Errorf("invalid admin level: %d", level)
+	}
+
+	res := r.containingShapes(pointFromCoord(loc))
+
+	kept := make([]s2.Shape, 0, len(res))
+	for _, shape := range res {
+		if r.locs[shape].adminLevel() <= level {
+			kept = append(kept, shape)
+		}
+	}
+
+	if len(kept) == 0 {
+		return Location{}, ErrLocationNotFound
+	}
+
+	return r.combineLocations(kept).only(fieldsUpTo(level)), nil
+}
+
+// Capabilities returns the finest AdminLevel each loaded dataset provides, by
+// dataset name, so callers can tell what a Rgeo can give, e.g. whether to show
+// a city field. Datasets with no shapes loaded are left out.
+//
+// The level of a dataset is the finest level of any of its shapes, including
+// ones added with AddFeature, and the level of a shape is the finest level
+// with any of its Location fields set, e.g. LevelProvince for a shape with a
+// Province or ProvinceCode. So it's inferred from the fields that were
+// actually loaded, after WithFields, rather than what the dataset is meant to
+// have, and a shape without any fields counts as LevelCountry. With the
+// included datasets this gives LevelCountry for Countries110 and Countries10,
+// LevelProvince for Provinces10, LevelCounty for US_Counties10 and LevelCity
+// for Cities10.
+func (r *Rgeo) Capabilities() map[string]AdminLevel {
+	ret := make(map[string]AdminLevel, len(r.levels))
+	for dataset, level := range r.levels {
+		ret[dataset] = level
+	}
+
+	return ret
+}
+
+// addLevel records the AdminLevel of a shape with the given Location loaded
+// into the named dataset, for Capabilities.
+func (r *Rgeo) addLevel(dataset string, l Location) {
+	if level, ok := r.levels[dataset]; !ok || l.adminLevel() > level {
+		r.levels[dataset] = l.adminLevel()
+	}
+}
+
+// adminLevel returns the finest AdminLevel with any fields set in l.
+func (l Location) adminLevel() AdminLevel {
+	f := l.Fields()
+	for level := LevelCity; level > LevelCountry; level-- {
+		if f&levelFields[level] != 0 {
+			return level
+		}
+	}
+
+	return LevelCountry
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+// levelsGeo has a city inside a province inside a country. The city is first
+// and has its own country code, so it wins for ReverseGeocode.
+const levelsGeo = `{
+	"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"name_conve":"Town","ISO_A3":"TWN"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[1,3],[2,3],[2,4],[1,4],[1,3]]]}},
+			{"type":"Feature",
+			"properties":{"name":"North","iso_3166_2":"LND-N"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,2],[4,2],[4,4],[0,4],[0,2]]]}},
+			{"type":"Feature",
+			"properties":{"ADMIN":"Land","ISO_A3":"LND"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,0],[4,0],[4,4],[0,4],[0,0]]]}}
+		]
+	}`
+
+func TestReverseGeocodeAtLevel(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, levelsGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	country := Location{Country: "Land", CountryCode3: "LND"}
+	province := Location{Country: "Land", CountryCode3: "LND", Province: "North", ProvinceCode: "LND-N"}
+
+	tests := []struct {
+		name     string
+		in       orb.Point
+		level    AdminLevel
+		expected Location
+		err      error
+	}{
+		{"CountryInCity", orb.Point{1.5, 3.5}, LevelCountry, country, nil},
+		{"ProvinceInCity", orb.Point{1.5, 3.5}, LevelProvince, province, nil},
+		{"CountyNotLoaded", orb.Point{1.5, 3.5}, LevelCounty, province, nil},
+		{"City", orb.Point{1.5, 3.5}, LevelCity, Location{
+			Country: "Land", CountryCode3: "TWN", Province: "North", ProvinceCode: "LND-N", City: "Town",
+		}, nil},
+		{"ProvinceOutsideProvinces", orb.Point{1.5, 1}, LevelProvince, country, nil},
+		{"NotFound", orb.Point{5, 5}, LevelCity, Location{}, ErrLocationNotFound},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			loc, err := r.ReverseGeocodeAtLevel(test.in, test.level)
+			if !errors.Is(err, test.err) {
+				t.Errorf("expected error: %v\n got: %v\n", test.err, err)
+			}
+			if diff := deep.Equal(test.expected, loc); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+
+	if _, err := r.ReverseGeocodeAtLevel(orb.Point{1.5, 3.5}, LevelCity+1); err == nil {
+		t.Error("expected error for invalid level")
+	}
+}
+
+func TestCapabilities(t *testing.T) {
+	levels := func() []byte { return compressData(t, levelsGeo) }
+	squares := func() []byte { return compressData(t, twoSquaresGeo) }
+
+	r, err := New(levels, squares)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]AdminLevel{
+		getFunctionName(levels):  LevelCity,
+		getFunctionName(squares): LevelCountry,
+	}
+	if diff := deep.Equal(expected, r.Capabilities()); diff != nil {
+		t.Error(diff)
+	}
+
+	// Dropping the finer fields lowers the level, as it's inferred from the
+	// fields that were loaded.
+	r, err = NewWithOptions([]func() []byte{levels}, WithFields(fieldsUpTo(LevelProvince)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := r.AddFeature(orb.Polygon{{{5, 5}, {6, 5}, {6, 6}, {5, 6}, {5, 5}}}, Location{County: "Added"}, "added"); err != nil {
+		t.Fatal(err)
+	}
+
+	expected = map[string]AdminLevel{
+		getFunctionName(levels): LevelProvince,
+		"added":                 LevelCountry,
+	}
+	if diff := deep.Equal(expected, r.Capabilities()); diff != nil {
+		t.Error(diff)
+	}
+}
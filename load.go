@@ -0,0 +1,289 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb/geojson"
+)
+
+// WithConcurrentLoading makes NewWithOptions decode all of the datasets at
+// the same time, and then convert their features to s2 polygons on all of the
+// CPUs, rather than doing one dataset at a time. The shapes are still added to
+// the index in the same order, so the results are the same either way.
+//
+// Building the index itself can't be split up, so how much faster it is
+// depends on the number of CPUs, BenchmarkLoading compares the two. All of the
+// datasets are held in memory at once though, so the peak memory use is
+// higher.
+func WithConcurrentLoading() Option {
+	return func(o *options) {
+		o.concurrent = true
+	}
+}
+
+// DedupCountries skips country shapes, ones with only the country fields,
+// which are exactly the same as one that's already been loaded by the same
+// NewWithOptions, with the same fields and the same polygon. This is for
+// loading Countries10 and Provinces10 together, as Provinces10 includes all of
+// the shapes of Countries10 along with the provinces, so without it they're
+// all in the index twice. The results of ReverseGeocode are the same with it,
+// it only makes the index smaller: for those two datasets it drops 258 shapes
+// and a fifth of the edges, which also makes loading about 10% faster.
+//
+// The first copy of each shape is kept, so the skipped ones aren't part of
+// the later datasets, e.g. ExportGeoJSONTo for Provinces10 doesn't include the
+// countries if Countries10 was loaded before it.
+func DedupCountries() Option {
+	return func(o *options) {
+		o.dedupCountries = true
+	}
+}
+
+// WithProgress calls progress as the features of each dataset are converted
+// and added to the index, e.g. to show a progress bar while loading
+// Provinces10. It's given the name of the dataset, as returned by
+// DatasetNames, the number of its features which have been processed so far,
+// and the number of features in it, including ones which are skipped.
+//
+// It's called every time another 1% of a dataset's features are done, and
+// always once done reaches total, so at most 101 times for each dataset however
+// big it is, and once with done and total both 0 for an empty dataset. Each
+// dataset is decoded from GeoJSON before the first call for it, and the s2
+// index is built after the last call, so neither of those is covered.
+//
+// With WithConcurrentLoading it can be called from more than one goroutine,
+// but never concurrently, and done only goes up for each dataset. The calls for
+// different datasets can be interleaved then.
+func WithProgress(progress func(dataset string, done, total int)) Option {
+	return func(o *options) {
+		o.progress = progress
+	}
+}
+
+// reportProgress calls the WithProgress callback, if there is one and done is
+// at the next step.
+func (r *Rgeo) reportProgress(dataset string, done, total int) {
+	if r.opts.progress == nil {
+		return
+	}
+
+	// A step of total/100 rounded up, at least 1.
+	step := (total + 99) / 100
+	if done == total || (step > 0 && done%step == 0) {
+		r.opts.progress(dataset, done, total)
+	}
+}
+
+// loadDatasets decodes the datasets, converts their features and adds them to
+// the index, for NewWithOptions. By default this is done one dataset at a
+// time, so that only one is decoded at once. With WithConcurrentLoading or
+// DedupCountries everything is worked out before anything is added to the
+// index, so that DedupCountries can compare all of the datasets.
+func (r *Rgeo) loadDatasets(datasets []func() []byte) error {
+	if !r.opts.concurrent && !r.opts.dedupCountries {
+		for i, dataset := range datasets {
+			fc, err := decodeDataset(dataset(), true, fmt.Sprintf("dataset %d", i))
+			if err != nil {
+				return err
+			}
+
+			if err := r.addFeatureCollection(fc, getFunctionName(dataset)); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	fcs := make([]*geojson.FeatureCollection, len(datasets))
+	errs := make([]error, len(datasets))
+
+	r.run(len(datasets), func(i int) {
+		fcs[i], errs[i] = decodeDataset(datasets[i](), true, fmt.Sprintf("dataset %d", i))
+	})
+
+	if err := firstError(errs); err != nil {
+		return err
+	}
+
+	// The features of all of the datasets are converted together, so that
+	// one large dataset doesn't leave the other workers idle.
+	type ref struct{ dataset, feature int }
+
+	var refs []ref
+	prepared := make([][]feature, len(fcs))
+	kept := make([][]bool, len(fcs))
+	for i, fc := range fcs {
+		prepared[i] = make([]feature, len(fc.Features))
+		kept[i] = make([]bool, len(fc.Features))
+
+		for j := range fc.Features {
+			refs = append(refs, ref{i, j})
+		}
+	}
+
+	var (
+		progressMu sync.Mutex
+		done       = make([]int, len(fcs))
+	)
+
+	featureErrs := make([]error, len(refs))
+	r.run(len(refs), func(n int) {
+		i, j := refs[n].dataset, refs[n].feature
+		f := fcs[i].Features[j]
+		prepared[i][j], kept[i][j], featureErrs[n] = r.prepareFeature(f.Geometry, f.Properties)
+
+		if r.opts.progress != nil {
+			progressMu.Lock()
+			done[i]++
+			r.reportProgress(getFunctionName(datasets[i]), done[i], len(fcs[i].Features))
+			progressMu.Unlock()
+		}
+	})
+
+	for i, fc := range fcs {
+		if len(fc.Features) == 0 {
+			r.reportProgress(getFunctionName(datasets[i]), 0, 0)
+		}
+	}
+
+	if err := firstError(featureErrs); err != nil {
+		return err
+	}
+
+	if r.opts.dedupCountries {
+		dedupCountries(prepared, kept)
+	}
+
+	for i, dataset := range datasets {
+		name := getFunctionName(dataset)
+		shpGeoms := r.datasetGeoms(name, len(prepared[i]))
+		strs := make(interner)
+
+		for j, f := range prepared[i] {
+			if kept[i][j] {
+				r.insertFeature(f, name, shpGeoms, strs)
+			}
+		}
+	}
+
+	return nil
+}
+
+// dedupCountries sets kept to false for the country shapes in prepared which
+// are the same as an earlier one, for DedupCountries.
+func dedupCountries(prepared [][]feature, kept [][]bool) {
+	seen := make(map[Location][]*s2.Polygon)
+
+	for i := range prepared {
+		for j, f := range prepared[i] {
+			if !kept[i][j] || f.loc.Country == "" || f.loc.adminLevel() != LevelCountry {
+				continue
+			}
+
+			duplicate := false
+			for _, p := range seen[f.loc] {
+				if samePolygon(p, f.shape) {
+					duplicate = true
+					break
+				}
+			}
+
+			if duplicate {
+				kept[i][j] = false
+				continue
+			}
+
+			seen[f.loc] = append(seen[f.loc], f.shape)
+		}
+	}
+}
+
+// samePolygon reports whether a and b have the same loops, with the same
+// vertices in the same order.
+func samePolygon(a, b *s2.Polygon) bool {
+	if a.NumLoops() != b.NumLoops() || a.NumEdges() != b.NumEdges() {
+		return false
+	}
+
+	for i := 0; i < a.NumLoops(); i++ {
+		if !a.Loop(i).Equal(b.Loop(i)) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// run calls fn for 0 to n-1, concurrently with WithConcurrentLoading.
+func (r *Rgeo) run(n int, fn func(i int)) {
+	if !r.opts.concurrent {
+		for i := 0; i < n; i++ {
+			fn(i)
+		}
+
+		return
+	}
+
+	workers := runtime.GOMAXPROCS(0)
+	if workers > n {
+		workers = n
+	}
+
+	var (
+		wg   sync.WaitGroup
+		mu   sync.Mutex
+		next int
+	)
+
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+
+			for {
+				mu.Lock()
+				i := next
+				next++
+				mu.Unlock()
+
+				if i >= n {
+					return
+				}
+
+				fn(i)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+// firstError returns the first error in errs which isn't nil.
+func firstError(errs []error) error {
+	for _, err := range errs {
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/geojson"
+)
+
+func TestLoadingOptions(t *testing.T) {
+	// The province dataset has a copy of the country, like Provinces10 has
+	// the shapes of Countries10, and a country which is only the same in name.
+	countryGeo := `{
+		"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"ADMIN":"Land","ISO_A3":"LND","NE_ID":1},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,0],[4,0],[4,4],[0,4],[0,0]]]}},
+			{"type":"Feature",
+			"properties":{"ADMIN":"Other","ISO_A3":"OTH","NE_ID":2},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[5,0],[6,0],[6,1],[5,1],[5,0]]]}}
+		]
+	}`
+	provinceGeo := `{
+		"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"name":"North","iso_3166_2":"LND-N","ADMIN":"Land","ne_id":3},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,2],[4,2],[4,4],[0,4],[0,2]]]}},
+			{"type":"Feature",
+			"properties":{"ADMIN":"Land","ISO_A3":"LND","NE_ID":1},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,0],[4,0],[4,4],[0,4],[0,0]]]}},
+			{"type":"Feature",
+			"properties":{"ADMIN":"Other","ISO_A3":"OTH","NE_ID":2},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[5,0],[6.5,0],[6.5,1],[5,1],[5,0]]]}}
+		]
+	}`
+	countries := func() []byte { return compressData(t, countryGeo) }
+	provinces := func() []byte { return compressData(t, provinceGeo) }
+	datasets := []func() []byte{countries, provinces}
+
+	naive, err := NewWithOptions(datasets)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name   string
+		opts   []Option
+		shapes int
+	}{
+		{"Concurrent", []Option{WithConcurrentLoading()}, 5},
+		{"Dedup", []Option{DedupCountries()}, 4},
+		{"ConcurrentDedup", []Option{WithConcurrentLoading(), DedupCountries()}, 4},
+	}
+
+	points := []orb.Point{{1, 1}, {1, 3}, {5.5, 0.5}, {6.2, 0.5}, {10, 10}}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			r, err := NewWithOptions(datasets, test.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if n := r.index.Len(); n != test.shapes {
+				t.Errorf("expected %d shapes, got: %d", test.shapes, n)
+			}
+
+			for _, p := range points {
+				expected, expErr := naive.ReverseGeocode(p)
+				loc, err := r.ReverseGeocode(p)
+				if !errors.Is(err, expErr) {
+					t.Errorf("%v: expected error: %v, got: %v", p, expErr, err)
+				}
+				if diff := deep.Equal(expected, loc); diff != nil {
+					t.Errorf("%v: %v", p, diff)
+				}
+			}
+
+			if diff := deep.Equal(naive.DatasetNames(), r.DatasetNames()); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+
+	// Concurrent loading adds the shapes in the same order.
+	r, err := NewWithOptions(datasets, WithConcurrentLoading())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for i := int32(0); i < int32(r.index.Len()); i++ {
+		if diff := deep.Equal(naive.locs[naive.index.Shape(i)], r.locs[r.index.Shape(i)]); diff != nil {
+			t.Errorf("shape %d: %v", i, diff)
+		}
+	}
+
+	// Errors are reported for the first dataset that has one.
+	bad := func() []byte { return []byte("not gzip") }
+	_, err = NewWithOptions([]func() []byte{countries, bad}, WithConcurrentLoading())
+	if err == nil || !strings.Contains(err.Error(), "dataset 1") {
+		t.Errorf("expected an error for dataset 1, got: %v", err)
+	}
+}
+
+func TestWithProgress(t *testing.T) {
+	fc := geojson.NewFeatureCollection()
+	for i := 0; i < 250; i++ {
+		x := float64(i % 50)
+		y := float64(i / 50)
+		fc.Append(geojson.NewFeature(orb.Polygon{{{x, y}, {x + 1, y}, {x + 1, y + 1}, {x, y + 1}, {x, y}}}))
+	}
+	b, err := fc.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	squares := func() []byte { return compressData(t, string(b)) }
+	empty := func() []byte { return compressData(t, `{"type":"FeatureCollection","features":[]}`) }
+
+	for _, concurrent := range []bool{false, true} {
+		type call struct{ done, total int }
+		calls := make(map[string][]call)
+
+		opts := []Option{WithProgress(func(dataset string, done, total int) {
+			calls[dataset] = append(calls[dataset], call{done, total})
+		})}
+		if concurrent {
+			opts = append(opts, WithConcurrentLoading())
+		}
+
+		if _, err := NewWithOptions([]func() []byte{squares, empty}, opts...); err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := deep.Equal([]call{{0, 0}}, calls[getFunctionName(empty)]); diff != nil {
+			t.Error(concurrent, diff)
+		}
+
+		got := calls[getFunctionName(squares)]
+		if len(got) < 2 || len(got) > 101 {
+			t.Fatalf("concurrent %v: expected 2 to 101 calls, got %d", concurrent, len(got))
+		}
+		for i, c := range got {
+			if c.total != 250 || (i > 0 && c.done <= got[i-1].done) {
+				t.Errorf("concurrent %v: unexpected call %d: %v", concurrent, i, c)
+			}
+		}
+		if last := got[len(got)-1]; last.done != 250 {
+			t.Errorf("concurrent %v: expected the last call to be done, got %v", concurrent, last)
+		}
+	}
+}
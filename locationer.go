@@ -0,0 +1,102 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+// Locationer is implemented by Location, for code that takes geocoding results
+// from rgeo or other sources through an interface rather than the struct. Each
+// method returns the field of the same name, the one with the JSON key in
+// snake case, e.g. GetCountryCode2 for "country_code_2".
+type Locationer interface {
+	GetCountry() string
+	GetCountryLong() string
+	GetSovereign() string
+	GetCountryCode2() string
+	GetCountryCode3() string
+	GetCountryCodeNumeric() string
+	GetCallingCode() string
+	GetCurrencyCode() string
+	GetContinent() string
+	GetRegion() string
+	GetSubRegion() string
+	GetRegionCode() string
+	GetSubRegionCode() string
+	GetProvince() string
+	GetProvinceCode() string
+	GetCounty() string
+	GetCountyCode() string
+	GetCity() string
+	GetFeatureID() string
+}
+
+var _ Locationer = Location{}
+
+// GetCountry returns l.Country.
+func (l Location) GetCountry() string { return l.Country }
+
+// GetCountryLong returns l.CountryLong.
+func (l Location) GetCountryLong() string { return l.CountryLong }
+
+// GetSovereign returns l.Sovereign.
+func (l Location) GetSovereign() string { return l.Sovereign }
+
+// GetCountryCode2 returns l.CountryCode2.
+func (l Location) GetCountryCode2() string { return l.CountryCode2 }
+
+// GetCountryCode3 returns l.CountryCode3.
+func (l Location) GetCountryCode3() string { return l.CountryCode3 }
+
+// GetCountryCodeNumeric returns l.CountryCodeNumeric.
+func (l Location) GetCountryCodeNumeric() string { return l.CountryCodeNumeric }
+
+// GetCallingCode returns l.CallingCode.
+func (l Location) GetCallingCode() string { return l.CallingCode }
+
+// GetCurrencyCode returns l.CurrencyCode.
+func (l Location) GetCurrencyCode() string { return l.CurrencyCode }
+
+// GetContinent returns l.Continent.
+func (l Location) GetContinent() string { return l.Continent }
+
+// GetRegion returns l.Region.
+func (l Location) GetRegion() string { return l.Region }
+
+// GetSubRegion returns l.SubRegion, which has the JSON key "subregion".
+func (l Location) GetSubRegion() string { return l.SubRegion }
+
+// GetRegionCode returns l.RegionCode.
+func (l Location) GetRegionCode() string { return l.RegionCode }
+
+// GetSubRegionCode returns l.SubRegionCode, which has the JSON key
+// "subregion_code".
+func (l Location) GetSubRegionCode() string { return l.SubRegionCode }
+
+// GetProvince returns l.Province.
+func (l Location) GetProvince() string { return l.Province }
+
+// GetProvinceCode returns l.ProvinceCode.
+func (l Location) GetProvinceCode() string { return l.ProvinceCode }
+
+// GetCounty returns l.County.
+func (l Location) GetCounty() string { return l.County }
+
+// GetCountyCode returns l.CountyCode.
+func (l Location) GetCountyCode() string { return l.CountyCode }
+
+// GetCity returns l.City.
+func (l Location) GetCity() string { return l.City }
+
+// GetFeatureID returns l.FeatureID.
+func (l Location) GetFeatureID() string { return l.FeatureID }
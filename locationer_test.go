@@ -0,0 +1,66 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLocationer(t *testing.T) {
+	// Give every field a different value, then check each getter returns the
+	// one with the matching JSON key.
+	var loc Location
+	v := reflect.ValueOf(&loc).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		v.Field(i).SetString(v.Type().Field(i).Name)
+	}
+
+	b, err := json.Marshal(loc)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var byKey map[string]string
+	if err := json.Unmarshal(b, &byKey); err != nil {
+		t.Fatal(err)
+	}
+
+	var l Locationer = loc
+	lv := reflect.ValueOf(l)
+	lt := reflect.TypeOf((*Locationer)(nil)).Elem()
+
+	if lt.NumMethod() != v.NumField() {
+		t.Errorf("expected a getter for each of the %d fields, got %d", v.NumField(), lt.NumMethod())
+	}
+
+	for i := 0; i < lt.NumMethod(); i++ {
+		name := strings.TrimPrefix(lt.Method(i).Name, "Get")
+		field, ok := v.Type().FieldByName(name)
+		if !ok {
+			t.Errorf("%s: no field %s", lt.Method(i).Name, name)
+			continue
+		}
+
+		key := strings.Split(field.Tag.Get("json"), ",")[0]
+		got := lv.MethodByName(lt.Method(i).Name).Call(nil)[0].String()
+		if got != byKey[key] {
+			t.Errorf("%s: expected %q, got %q", lt.Method(i).Name, byKey[key], got)
+		}
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+/*
+Package locationpb converts rgeo Locations to and from Protocol Buffers, for
+services which pass results around as protobuf rather than JSON. The message is
+defined in location.proto, with a field for each Location field, so a Location
+goes through ToProto and LocationFromProto unchanged.
+
+It is a separate module so that users of the rgeo library don't have to depend
+on protobuf.
+*/
+package locationpb
+
+//go:generate protoc -I.. --go_out=.. --go_opt=paths=source_relative ../locationpb/location.proto
+
+import "github.com/sams96/rgeo"
+
+// ToProto converts an rgeo.Location to its protobuf message.
+func ToProto(l rgeo.Location) *Location {
+	return &Location{
+		Country:            l.Country,
+		CountryLong:        l.CountryLong,
+		Sovereign:          l.Sovereign,
+		CountryCode_2:      l.CountryCode2,
+		CountryCode_3:      l.CountryCode3,
+		CountryCodeNumeric: l.CountryCodeNumeric,
+		CallingCode:        l.CallingCode,
+		CurrencyCode:       l.CurrencyCode,
+		Continent:          l.Continent,
+		Region:             l.Region,
+		Subregion:          l.SubRegion,
+		RegionCode:         l.RegionCode,
+		SubregionCode:      l.SubRegionCode,
+		Province:           l.Province,
+		ProvinceCode:       l.ProvinceCode,
+		County:             l.County,
+		CountyCode:         l.CountyCode,
+		City:               l.City,
+		FeatureId:          l.FeatureID,
+	}
+}
+
+// LocationFromProto converts a protobuf message back to an rgeo.Location. A
+// nil message gives an empty Location.
+func LocationFromProto(p *Location) rgeo.Location {
+	return rgeo.Location{
+		Country:            p.GetCountry(),
+		CountryLong:        p.GetCountryLong(),
+		Sovereign:          p.GetSovereign(),
+		CountryCode2:       p.GetCountryCode_2(),
+		CountryCode3:       p.GetCountryCode_3(),
+		CountryCodeNumeric: p.GetCountryCodeNumeric(),
+		CallingCode:        p.GetCallingCode(),
+		CurrencyCode:       p.GetCurrencyCode(),
+		Continent:          p.GetContinent(),
+		Region:             p.GetRegion(),
+		SubRegion:          p.GetSubregion(),
+		RegionCode:         p.GetRegionCode(),
+		SubRegionCode:      p.GetSubregionCode(),
+		Province:           p.GetProvince(),
+		ProvinceCode:       p.GetProvinceCode(),
+		County:             p.GetCounty(),
+		CountyCode:         p.GetCountyCode(),
+		City:               p.GetCity(),
+		FeatureID:          p.GetFeatureId(),
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package locationpb
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/sams96/rgeo"
+	"google.golang.org/protobuf/proto"
+)
+
+func TestRoundTrip(t *testing.T) {
+	// Set every field to a different value, so a field which is missed or
+	// mixed up with another one shows up.
+	var l rgeo.Location
+	v := reflect.ValueOf(&l).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		v.Field(i).SetString(v.Type().Field(i).Name)
+	}
+
+	b, err := proto.Marshal(ToProto(l))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var p Location
+	if err := proto.Unmarshal(b, &p); err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := deep.Equal(l, LocationFromProto(&p)); diff != nil {
+		t.Error(diff)
+	}
+
+	if diff := deep.Equal(rgeo.Location{}, LocationFromProto(nil)); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestFieldNames(t *testing.T) {
+	// The proto field names are the JSON names of the Location fields.
+	fields := (&Location{}).ProtoReflect().Descriptor().Fields()
+
+	typ := reflect.TypeOf(rgeo.Location{})
+	if fields.Len() != typ.NumField() {
+		t.Fatalf("expected %d fields, got %d", typ.NumField(), fields.Len())
+	}
+
+	for i := 0; i < typ.NumField(); i++ {
+		json := typ.Field(i).Tag.Get("json")
+		json = json[:len(json)-len(",omitempty")]
+		if name := string(fields.Get(i).Name()); name != json {
+			t.Errorf("field %d: expected %s, got %s", i, json, name)
+		}
+	}
+}
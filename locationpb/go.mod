@@ -0,0 +1,17 @@
+module github.com/sams96/rgeo/locationpb
+
+go 1.23.0
+
+require (
+	github.com/go-test/deep v1.1.0
+	github.com/sams96/rgeo v1.2.0
+	google.golang.org/protobuf v1.36.9
+)
+
+require (
+	github.com/golang/geo v0.0.0-20230421003525-6adc56603217 // indirect
+	github.com/paulmach/orb v0.11.1 // indirect
+	go.mongodb.org/mongo-driver v1.11.4 // indirect
+)
+
+replace github.com/sams96/rgeo => ../
@@ -0,0 +1,317 @@
+// Copyright 2020 Sam Smith
+//
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not
+// use this file except in compliance with the License.  You may obtain a copy
+// of the License at
+//
+//    http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS, WITHOUT
+// WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.  See the
+// License for the specific language governing permissions and limitations
+// under the License.
+
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.9
+// 	protoc        (unknown)
+// source: locationpb/location.proto
+
+package locationpb
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// Location mirrors rgeo.Location, with a field for each of its fields in the
+// same order. Empty fields weren't in the loaded data.
+type Location struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Commonly used country name
+	Country string `protobuf:"bytes,1,opt,name=country,proto3" json:"country,omitempty"`
+	// Formal name of country
+	CountryLong string `protobuf:"bytes,2,opt,name=country_long,json=countryLong,proto3" json:"country_long,omitempty"`
+	// Name of the sovereign state, this differs from country for dependent
+	// territories
+	Sovereign string `protobuf:"bytes,3,opt,name=sovereign,proto3" json:"sovereign,omitempty"`
+	// ISO 3166-1 alpha-2 and alpha-3 codes
+	CountryCode_2 string `protobuf:"bytes,4,opt,name=country_code_2,json=countryCode2,proto3" json:"country_code_2,omitempty"`
+	CountryCode_3 string `protobuf:"bytes,5,opt,name=country_code_3,json=countryCode3,proto3" json:"country_code_3,omitempty"`
+	// ISO 3166-1 numeric code, e.g. "840"
+	CountryCodeNumeric string `protobuf:"bytes,6,opt,name=country_code_numeric,json=countryCodeNumeric,proto3" json:"country_code_numeric,omitempty"`
+	// E.164 calling code, e.g. "+44", and ISO 4217 currency code, e.g. "GBP"
+	CallingCode  string `protobuf:"bytes,7,opt,name=calling_code,json=callingCode,proto3" json:"calling_code,omitempty"`
+	CurrencyCode string `protobuf:"bytes,8,opt,name=currency_code,json=currencyCode,proto3" json:"currency_code,omitempty"`
+	Continent    string `protobuf:"bytes,9,opt,name=continent,proto3" json:"continent,omitempty"`
+	Region       string `protobuf:"bytes,10,opt,name=region,proto3" json:"region,omitempty"`
+	Subregion    string `protobuf:"bytes,11,opt,name=subregion,proto3" json:"subregion,omitempty"`
+	// UN M49 codes of the region and subregion, e.g. "150" and "154" for
+	// Northern Europe
+	RegionCode    string `protobuf:"bytes,18,opt,name=region_code,json=regionCode,proto3" json:"region_code,omitempty"`
+	SubregionCode string `protobuf:"bytes,19,opt,name=subregion_code,json=subregionCode,proto3" json:"subregion_code,omitempty"`
+	Province      string `protobuf:"bytes,12,opt,name=province,proto3" json:"province,omitempty"`
+	// ISO 3166-2 code
+	ProvinceCode string `protobuf:"bytes,13,opt,name=province_code,json=provinceCode,proto3" json:"province_code,omitempty"`
+	County       string `protobuf:"bytes,14,opt,name=county,proto3" json:"county,omitempty"`
+	// Natural Earth admin-2 code, e.g. "USA-53065"
+	CountyCode string `protobuf:"bytes,15,opt,name=county_code,json=countyCode,proto3" json:"county_code,omitempty"`
+	City       string `protobuf:"bytes,16,opt,name=city,proto3" json:"city,omitempty"`
+	// Identifier of the matched feature from the dataset, e.g. Natural Earth's
+	// NE_ID
+	FeatureId     string `protobuf:"bytes,17,opt,name=feature_id,json=featureId,proto3" json:"feature_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Location) Reset() {
+	*x = Location{}
+	mi := &file_locationpb_location_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Location) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Location) ProtoMessage() {}
+
+func (x *Location) ProtoReflect() protoreflect.Message {
+	mi := &file_locationpb_location_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Location.ProtoReflect.Descriptor instead.
+func (*Location) Descriptor() ([]byte, []int) {
+	return file_locationpb_location_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Location) GetCountry() string {
+	if x != nil {
+		return x.Country
+	}
+	return ""
+}
+
+func (x *Location) GetCountryLong() string {
+	if x != nil {
+		return x.CountryLong
+	}
+	return ""
+}
+
+func (x *Location) GetSovereign() string {
+	if x != nil {
+		return x.Sovereign
+	}
+	return ""
+}
+
+func (x *Location) GetCountryCode_2() string {
+	if x != nil {
+		return x.CountryCode_2
+	}
+	return ""
+}
+
+func (x *Location) GetCountryCode_3() string {
+	if x != nil {
+		return x.CountryCode_3
+	}
+	return ""
+}
+
+func (x *Location) GetCountryCodeNumeric() string {
+	if x != nil {
+		return x.CountryCodeNumeric
+	}
+	return ""
+}
+
+func (x *Location) GetCallingCode() string {
+	if x != nil {
+		return x.CallingCode
+	}
+	return ""
+}
+
+func (x *Location) GetCurrencyCode() string {
+	if x != nil {
+		return x.CurrencyCode
+	}
+	return ""
+}
+
+func (x *Location) GetContinent() string {
+	if x != nil {
+		return x.Continent
+	}
+	return ""
+}
+
+func (x *Location) GetRegion() string {
+	if x != nil {
+		return x.Region
+	}
+	return ""
+}
+
+func (x *Location) GetSubregion() string {
+	if x != nil {
+		return x.Subregion
+	}
+	return ""
+}
+
+func (x *Location) GetRegionCode() string {
+	if x != nil {
+		return x.RegionCode
+	}
+	return ""
+}
+
+func (x *Location) GetSubregionCode() string {
+	if x != nil {
+		return x.SubregionCode
+	}
+	return ""
+}
+
+func (x *Location) GetProvince() string {
+	if x != nil {
+		return x.Province
+	}
+	return ""
+}
+
+func (x *Location) GetProvinceCode() string {
+	if x != nil {
+		return x.ProvinceCode
+	}
+	return ""
+}
+
+func (x *Location) GetCounty() string {
+	if x != nil {
+		return x.County
+	}
+	return ""
+}
+
+func (x *Location) GetCountyCode() string {
+	if x != nil {
+		return x.CountyCode
+	}
+	return ""
+}
+
+func (x *Location) GetCity() string {
+	if x != nil {
+		return x.City
+	}
+	return ""
+}
+
+func (x *Location) GetFeatureId() string {
+	if x != nil {
+		return x.FeatureId
+	}
+	return ""
+}
+
+var File_locationpb_location_proto protoreflect.FileDescriptor
+
+const file_locationpb_location_proto_rawDesc = "" +
+	"\n" +
+	"\x19locationpb/location.proto\x12\x10rgeo.location.v1\"\xf4\x04\n" +
+	"\bLocation\x12\x18\n" +
+	"\acountry\x18\x01 \x01(\tR\acountry\x12!\n" +
+	"\fcountry_long\x18\x02 \x01(\tR\vcountryLong\x12\x1c\n" +
+	"\tsovereign\x18\x03 \x01(\tR\tsovereign\x12$\n" +
+	"\x0ecountry_code_2\x18\x04 \x01(\tR\fcountryCode2\x12$\n" +
+	"\x0ecountry_code_3\x18\x05 \x01(\tR\fcountryCode3\x120\n" +
+	"\x14country_code_numeric\x18\x06 \x01(\tR\x12countryCodeNumeric\x12!\n" +
+	"\fcalling_code\x18\a \x01(\tR\vcallingCode\x12#\n" +
+	"\rcurrency_code\x18\b \x01(\tR\fcurrencyCode\x12\x1c\n" +
+	"\tcontinent\x18\t \x01(\tR\tcontinent\x12\x16\n" +
+	"\x06region\x18\n" +
+	" \x01(\tR\x06region\x12\x1c\n" +
+	"\tsubregion\x18\v \x01(\tR\tsubregion\x12\x1f\n" +
+	"\vregion_code\x18\x12 \x01(\tR\n" +
+	"regionCode\x12%\n" +
+	"\x0esubregion_code\x18\x13 \x01(\tR\rsubregionCode\x12\x1a\n" +
+	"\bprovince\x18\f \x01(\tR\bprovince\x12#\n" +
+	"\rprovince_code\x18\r \x01(\tR\fprovinceCode\x12\x16\n" +
+	"\x06county\x18\x0e \x01(\tR\x06county\x12\x1f\n" +
+	"\vcounty_code\x18\x0f \x01(\tR\n" +
+	"countyCode\x12\x12\n" +
+	"\x04city\x18\x10 \x01(\tR\x04city\x12\x1d\n" +
+	"\n" +
+	"feature_id\x18\x11 \x01(\tR\tfeatureIdB#Z!github.com/sams96/rgeo/locationpbb\x06proto3"
+
+var (
+	file_locationpb_location_proto_rawDescOnce sync.Once
+	file_locationpb_location_proto_rawDescData []byte
+)
+
+func file_locationpb_location_proto_rawDescGZIP() []byte {
+	file_locationpb_location_proto_rawDescOnce.Do(func() {
+		file_locationpb_location_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_locationpb_location_proto_rawDesc), len(file_locationpb_location_proto_rawDesc)))
+	})
+	return file_locationpb_location_proto_rawDescData
+}
+
+var file_locationpb_location_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_locationpb_location_proto_goTypes = []any{
+	(*Location)(nil), // 0: rgeo.location.v1.Location
+}
+var file_locationpb_location_proto_depIdxs = []int32{
+	0, // [0:0] is the sub-list for method output_type
+	0, // [0:0] is the sub-list for method input_type
+	0, // [0:0] is the sub-list for extension type_name
+	0, // [0:0] is the sub-list for extension extendee
+	0, // [0:0] is the sub-list for field type_name
+}
+
+func init() { file_locationpb_location_proto_init() }
+func file_locationpb_location_proto_init() {
+	if File_locationpb_location_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_locationpb_location_proto_rawDesc), len(file_locationpb_location_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_locationpb_location_proto_goTypes,
+		DependencyIndexes: file_locationpb_location_proto_depIdxs,
+		MessageInfos:      file_locationpb_location_proto_msgTypes,
+	}.Build()
+	File_locationpb_location_proto = out.File
+	file_locationpb_location_proto_goTypes = nil
+	file_locationpb_location_proto_depIdxs = nil
+}
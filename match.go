@@ -0,0 +1,211 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+// Match is a single shape found by NearestMatches, IntersectingMatches or
+// PathMatches. Unlike the methods returning Locations, these give one Match
+// per shape, so the same Location can appear more than once if it is in more
+// than one shape or dataset.
+type Match struct {
+	// Location of the shape.
+	Location
+
+	// Distance in meters, what it's measured to depends on the method:
+	//   - NearestMatches: from the point to the shape, 0 if the shape contains
+	//     it.
+	//   - IntersectingMatches: always 0.
+	//   - PathMatches: along the path, from its start to where it first
+	//     reaches the shape.
+	Distance float64
+
+	// Dataset is the name of the dataset the shape was loaded from, as
+	// returned by DatasetNames.
+	Dataset string
+
+	// Shape is the shape in the index returned by ShapeIndex, it must be
+	// treated as read only.
+	Shape s2.Shape
+}
+
+// NearestMatches returns a Match for every shape containing loc or within
+// maxMeters of it, sorted by Distance, with ties in the order the shapes were
+// loaded. If there are none it returns ErrLocationNotFound.
+//
+// This is the all shapes version of ReverseGeocodeOrNearest, and distances are
+// measured in the same way.
+func (r *Rgeo) NearestMatches(loc orb.Point, maxMeters float64) ([]Match, error) {
+	if maxMeters < 0 || math.IsNaN(maxMeters) {
+		return nil, fmt.Errorf("invalid distance: %v", maxMeters)
+	}
+
+	p := pointFromCoord(loc)
+
+	dists := make(map[s2.Shape]float64)
+	if maxMeters > 0 {
+		for id, d := range r.edgeGrid().distances(p, chordAngleFromMeters(maxMeters)) {
+			dists[r.index.Shape(id)] = metersFromChordAngle(d)
+		}
+	}
+
+	for _, shape := range r.containingShapes(p) {
+		dists[shape] = 0
+	}
+
+	if len(dists) == 0 {
+		return nil, ErrLocationNotFound
+	}
+
+	set := make(map[s2.Shape]bool, len(dists))
+	for shape := range dists {
+		set[shape] = true
+	}
+
+	matches := r.matches(r.inLoadOrder(set), dists)
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Distance < matches[j].Distance
+	})
+
+	return matches, nil
+}
+
+// IntersectingMatches returns a Match for every shape which intersects the
+// given Polygon or MultiPolygon, in the order they were loaded. If there are
+// none it returns ErrLocationNotFound.
+func (r *Rgeo) IntersectingMatches(g orb.Geometry) ([]Match, error) {
+	p, err := polygonFromGeometry(g, options{})
+	if err != nil {
+		return nil, fmt.Errorf("bad polygon: %w", err)
+	}
+
+	shapes := r.intersectingShapes(p)
+	if len(shapes) == 0 {
+		return nil, ErrLocationNotFound
+	}
+
+	return r.matches(shapes, nil), nil
+}
+
+// PathMatches returns a Match for every shape the path goes through, including
+// ones it only touches the border of, in the order the path reaches them. If
+// there are none it returns ErrLocationNotFound.
+//
+// The path is made of great circle edges between its points, like the edges of
+// the loaded shapes.
+func (r *Rgeo) PathMatches(path orb.LineString) ([]Match, error) {
+	if len(path) == 0 {
+		return nil, errors.New("empty path")
+	}
+
+	dists := make(map[s2.Shape]float64)
+	var order []s2.Shape
+	reach := func(shape s2.Shape, meters float64) {
+		if prev, ok := dists[shape]; !ok {
+			order = append(order, shape)
+		} else if prev <= meters {
+			return
+		}
+
+		dists[shape] = meters
+	}
+
+	var (
+		crossings = s2.NewCrossingEdgeQuery(r.index)
+		along     float64
+	)
+
+	for i, pt := range path {
+		a := pointFromCoord(pt)
+		for _, shape := range r.containingShapes(a) {
+			reach(shape, along)
+		}
+
+		if i == len(path)-1 {
+			break
+		}
+
+		b := pointFromCoord(path[i+1])
+		for shape, edges := range crossings.CrossingsEdgeMap(a, b, s2.CrossingTypeAll) {
+			for _, e := range edges {
+				edge := shape.Edge(e)
+				reach(shape, along+a.Distance(crossingPoint(a, b, edge)).Radians()*earthRadiusMeters)
+			}
+		}
+
+		along += a.Distance(b).Radians() * earthRadiusMeters
+	}
+
+	if len(order) == 0 {
+		return nil, ErrLocationNotFound
+	}
+
+	matches := r.matches(order, dists)
+	sort.SliceStable(matches, func(i, j int) bool {
+		return matches[i].Distance < matches[j].Distance
+	})
+
+	return matches, nil
+}
+
+// crossingPoint returns the point where the edge crosses or touches ab.
+func crossingPoint(a, b s2.Point, edge s2.Edge) s2.Point {
+	if s2.CrossingSign(a, b, edge.V0, edge.V1) == s2.Cross {
+		return s2.Intersection(a, b, edge.V0, edge.V1)
+	}
+
+	// They share a vertex, or one of the edges ends on the other.
+	v := edge.V0
+	if s2.DistanceFromSegment(edge.V1, a, b) < s2.DistanceFromSegment(edge.V0, a, b) {
+		v = edge.V1
+	}
+
+	return s2.Project(v, a, b)
+}
+
+// matches returns a Match for each of the shapes, with distances from dists.
+func (r *Rgeo) matches(shapes []s2.Shape, dists map[s2.Shape]float64) []Match {
+	ret := make([]Match, len(shapes))
+	for i, shape := range shapes {
+		ret[i] = Match{
+			Location: r.locs[shape],
+			Distance: dists[shape],
+			Dataset:  r.shapeDataset(shape),
+			Shape:    shape,
+		}
+	}
+
+	return ret
+}
+
+// shapeDataset returns the name of the dataset the shape was loaded from.
+func (r *Rgeo) shapeDataset(shape s2.Shape) string {
+	for _, name := range r.datasets {
+		if _, ok := r.geoms[name][shape]; ok {
+			return name
+		}
+	}
+
+	return ""
+}
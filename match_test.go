@@ -0,0 +1,151 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/geojson"
+)
+
+// matchSummary is the part of a Match that's compared in the tests.
+type matchSummary struct {
+	country  string
+	distance float64
+}
+
+func checkMatches(t *testing.T, expected []matchSummary, got []Match) {
+	t.Helper()
+
+	if len(got) != len(expected) {
+		t.Fatalf("expected %d matches, got: %+v", len(expected), got)
+	}
+
+	for i, m := range got {
+		if m.Country != expected[i].country {
+			t.Errorf("match %d: expected country: %s, got: %s", i, expected[i].country, m.Country)
+		}
+		if math.Abs(m.Distance-expected[i].distance) > expected[i].distance*0.01+1 {
+			t.Errorf("match %d: expected distance: %f, got: %f", i, expected[i].distance, m.Distance)
+		}
+		if m.Dataset != "squares" {
+			t.Errorf("match %d: expected dataset: squares, got: %s", i, m.Dataset)
+		}
+		if m.Shape == nil {
+			t.Errorf("match %d: nil shape", i)
+		}
+	}
+}
+
+func newTwoSquares(t *testing.T) *Rgeo {
+	t.Helper()
+
+	fc, err := geojson.UnmarshalFeatureCollection([]byte(twoSquaresGeo))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewFromFeatureCollection(fc, "squares")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return r
+}
+
+func TestNearestMatches(t *testing.T) {
+	r := newTwoSquares(t)
+
+	tests := []struct {
+		name      string
+		in        orb.Point
+		maxMeters float64
+		expected  []matchSummary
+		err       error
+	}{
+		{"Inside", orb.Point{0.5, 0.5}, 0, []matchSummary{{"West", 0}}, nil},
+		{"InsideAndNear", orb.Point{0.5, 0.5}, 200000, []matchSummary{{"West", 0}, {"East", 166800}}, nil},
+		{"Between", orb.Point{1.4, 0.5}, 100000, []matchSummary{{"West", 44480}, {"East", 66720}}, nil},
+		{"OnlyOne", orb.Point{1.4, 0.5}, 50000, []matchSummary{{"West", 44480}}, nil},
+		{"None", orb.Point{1.4, 0.5}, 1000, nil, ErrLocationNotFound},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := r.NearestMatches(test.in, test.maxMeters)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error: %v\n got: %v\n", test.err, err)
+			}
+			checkMatches(t, test.expected, got)
+		})
+	}
+
+	if _, err := r.NearestMatches(orb.Point{0, 0}, -1); err == nil {
+		t.Error("expected error for negative distance")
+	}
+}
+
+func TestIntersectingMatches(t *testing.T) {
+	r := newTwoSquares(t)
+
+	both := orb.Polygon{{{0.5, 0.2}, {2.5, 0.2}, {2.5, 0.8}, {0.5, 0.8}, {0.5, 0.2}}}
+	got, err := r.IntersectingMatches(both)
+	if err != nil {
+		t.Fatal(err)
+	}
+	checkMatches(t, []matchSummary{{"West", 0}, {"East", 0}}, got)
+
+	between := orb.Polygon{{{1.2, 0.2}, {1.8, 0.2}, {1.8, 0.8}, {1.2, 0.8}, {1.2, 0.2}}}
+	if _, err := r.IntersectingMatches(between); !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("expected error: %v\n got: %v\n", ErrLocationNotFound, err)
+	}
+
+	if _, err := r.IntersectingMatches(orb.Point{0.5, 0.5}); err == nil {
+		t.Error("expected error for point")
+	}
+}
+
+func TestPathMatches(t *testing.T) {
+	r := newTwoSquares(t)
+
+	tests := []struct {
+		name     string
+		in       orb.LineString
+		expected []matchSummary
+		err      error
+	}{
+		{"Across", orb.LineString{{-1, 0.5}, {4, 0.5}}, []matchSummary{{"West", 111200}, {"East", 333600}}, nil},
+		{"Backwards", orb.LineString{{4, 0.5}, {-1, 0.5}}, []matchSummary{{"East", 111200}, {"West", 333600}}, nil},
+		{"FromInside", orb.LineString{{0.5, 0.5}, {1.5, 0.5}, {2.5, 0.5}}, []matchSummary{{"West", 0}, {"East", 166800}}, nil},
+		{"SinglePoint", orb.LineString{{2.5, 0.5}}, []matchSummary{{"East", 0}}, nil},
+		{"Between", orb.LineString{{1.5, -1}, {1.5, 2}}, nil, ErrLocationNotFound},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := r.PathMatches(test.in)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error: %v\n got: %v\n", test.err, err)
+			}
+			checkMatches(t, test.expected, got)
+		})
+	}
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+// MergeGeometries flattens a slice of Polygons and MultiPolygons, such as the
+// geometries returned by GetGeometry, into a single MultiPolygon. The polygons
+// are copied across as they are, so holes are preserved, but overlapping
+// polygons aren't dissolved into each other. Any other type of geometry gives
+// an error.
+func MergeGeometries(geoms []orb.Geometry) (orb.MultiPolygon, error) {
+	var mp orb.MultiPolygon
+	for i, g := range geoms {
+		switch g := g.(type) {
+		case orb.Polygon:
+			mp = append(mp, g)
+		case orb.MultiPolygon:
+			mp = append(mp, g...)
+		default:
+			return nil, fmt.Errorf("geometry %d: needs Polygon or MultiPolygon, got %T", i, g)
+		}
+	}
+
+	return mp, nil
+}
+
+// Merge returns a new Rgeo with the datasets of both r and other, e.g. to query
+// data built in separate steps together. The inputs aren't changed and can
+// still be used, and adding to the result with AddFeature doesn't change them
+// either. The shapes themselves are shared rather than copied, as they're
+// never changed.
+//
+// The shapes of r come first, in the order they were loaded, followed by the
+// shapes of other, which is the order used by ShapeFirst. Each shape keeps the
+// Location and dataset options from the Rgeo it was loaded into, like its
+// WithDatasetPriority, but the query options, like WithShapePreference and
+// WithCityPreference, are taken from r for all of them. City populations for
+// CityLargestPopulation are only there for shapes loaded with it.
+//
+// Likewise, the geometry of a dataset is only kept if the Rgeo it came from
+// kept it, so GetGeometry gives the same error for it as before. The
+// properties for ReverseGeocodeProperties are kept if either was loaded
+// WithProperties, shapes from the other one just don't have any.
+//
+// A dataset name can only be in one of them, otherwise Merge returns an error
+// listing the names in both. The s2 index is built again from all of the
+// shapes, as shapes can't be added to a built index.
+func (r *Rgeo) Merge(other *Rgeo) (*Rgeo, error) {
+	if other == nil {
+		return nil, errors.New("missing parameter: other")
+	}
+
+	var both []string
+	for _, name := range other.datasets {
+		if _, ok := r.geoms[name]; ok {
+			both = append(both, name)
+		}
+	}
+	if len(both) > 0 {
+		sort.Strings(both)
+		return nil, fmt.Errorf("datasets loaded in both: %v", both)
+	}
+
+	ret := newRgeo()
+	ret.opts = r.opts
+
+	for _, in := range []*Rgeo{r, other} {
+		for i := int32(0); i < int32(in.index.Len()); i++ {
+			shape := in.index.Shape(i)
+			ret.index.Add(shape)
+			ret.locs[shape] = in.locs[shape]
+			ret.copyShapeOptions(in, shape)
+		}
+
+		for _, name := range in.datasets {
+			shpGeoms := ret.datasetGeoms(name, len(in.geoms[name]))
+			for shape, geom := range in.geoms[name] {
+				shpGeoms[shape] = geom
+			}
+
+			if shapes, ok := in.order[name]; ok {
+				ret.order[name] = append([]s2.Shape(nil), shapes...)
+			}
+			if level, ok := in.levels[name]; ok {
+				ret.levels[name] = level
+			}
+		}
+	}
+
+	// Each dataset keeps its geometry only if it was kept in the Rgeo it came
+	// from.
+	if r.opts.geometryDatasets != nil || other.opts.geometryDatasets != nil {
+		kept := make(map[string]bool)
+		for _, in := range []*Rgeo{r, other} {
+			for _, name := range in.datasets {
+				if in.keepsGeometry(name) {
+					kept[name] = true
+				}
+			}
+		}
+		ret.opts.geometryDatasets = kept
+	}
+	ret.opts.properties = r.opts.properties || other.opts.properties
+
+	ret.query = s2.NewContainsPointQuery(ret.index, s2.VertexModelOpen)
+
+	return ret, nil
+}
+
+// copyShapeOptions copies everything from in that's kept about shape, apart
+// from its Location, to r. The areas are worked out if r needs them and in
+// didn't.
+func (r *Rgeo) copyShapeOptions(in *Rgeo, shape s2.Shape) {
+	if v, ok := in.cityPops[shape]; ok {
+		r.cityPops[shape] = v
+	}
+
+	if v, ok := in.areas[shape]; ok {
+		r.areas[shape] = v
+	} else if p, ok := shape.(*s2.Polygon); ok && r.opts.shapePreference != ShapeFirst {
+		r.areas[shape] = p.Area()
+	}
+
+	if v, ok := in.priorities[shape]; ok {
+		r.priorities[shape] = v
+	}
+
+	if v, ok := in.validity[shape]; ok {
+		r.validity[shape] = v
+	}
+
+	if v, ok := in.altitudes[shape]; ok {
+		r.altitudes[shape] = v
+	}
+
+	if v, ok := in.props[shape]; ok {
+		r.props[shape] = v
+	}
+}
@@ -0,0 +1,168 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+func TestMergeGeometries(t *testing.T) {
+	square := orb.Polygon{
+		{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}},
+		{{1, 1}, {1, 2}, {2, 2}, {2, 1}, {1, 1}},
+	}
+	a := orb.Polygon{{{5, 0}, {6, 0}, {6, 1}, {5, 1}, {5, 0}}}
+	b := orb.Polygon{{{7, 0}, {8, 0}, {8, 1}, {7, 1}, {7, 0}}}
+
+	got, err := MergeGeometries([]orb.Geometry{square, orb.MultiPolygon{a, b}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(orb.MultiPolygon{square, a, b}, got); diff != nil {
+		t.Error(diff)
+	}
+
+	_, err = MergeGeometries([]orb.Geometry{square, orb.Point{0, 0}})
+	expected := "geometry 1: needs Polygon or MultiPolygon, got orb.Point"
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected error: %s\n got: %s\n", expected, err)
+	}
+}
+
+func TestMerge(t *testing.T) {
+	squares := func() []byte { return compressData(t, twoSquaresGeo) }
+	square := func() []byte { return compressData(t, squareGeo) }
+	enclave := func() []byte { return compressData(t, enclaveGeo) }
+
+	a, err := New(squares)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Outer is loaded after East, so it only wins on its priority.
+	b, err := NewWithOptions([]func() []byte{square, enclave}, WithDatasetPriority(enclave, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := a.Merge(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{getFunctionName(square), getFunctionName(enclave), getFunctionName(squares)}
+	sort.Strings(expected)
+	if diff := deep.Equal(expected, r.DatasetNames()); diff != nil {
+		t.Error(diff)
+	}
+
+	tests := []struct {
+		in       orb.Point
+		expected Location
+	}{
+		{orb.Point{2.5, 0.5}, Location{Country: "Outer", CountryCode3: "EST", Continent: "Europe"}},
+		{orb.Point{0.5, 52.5}, Location{CountryCode3: "TST"}},
+		{orb.Point{5.5, 0.5}, Location{}},
+	}
+	for _, test := range tests {
+		loc, _ := r.ReverseGeocode(test.in)
+		if diff := deep.Equal(test.expected, loc); diff != nil {
+			t.Error(test.in, diff)
+		}
+	}
+
+	if _, err := r.GetGeometry(orb.Point{0.5, 52.5}, getFunctionName(square)); err != nil {
+		t.Error(err)
+	}
+
+	// Adding to the result doesn't change the inputs.
+	added := orb.Polygon{{{10, 10}, {11, 10}, {11, 11}, {10, 11}, {10, 10}}}
+	if err := r.AddFeature(added, Location{Country: "Added"}, getFunctionName(squares)); err != nil {
+		t.Fatal(err)
+	}
+	if shapes, _ := a.DatasetShapes(getFunctionName(squares)); len(shapes) != 2 {
+		t.Errorf("expected the input to keep 2 shapes, got %d", len(shapes))
+	}
+	if _, err := a.ReverseGeocode(orb.Point{10.5, 10.5}); !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("expected the input not to have the added shape, got %v", err)
+	}
+
+	if _, err := r.Merge(b); err == nil {
+		t.Error("expected error for datasets in both")
+	}
+	if _, err := a.Merge(nil); err == nil {
+		t.Error("expected error for nil")
+	}
+}
+
+func TestMerge_Options(t *testing.T) {
+	squares := func() []byte { return compressData(t, twoSquaresGeo) }
+	square := func() []byte { return compressData(t, squareGeo) }
+	enclave := func() []byte { return compressData(t, enclaveGeo) }
+
+	// a keeps all of its geometry but no properties, b only keeps the geometry
+	// of square but has properties.
+	a, err := New(squares)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := NewWithOptions([]func() []byte{square, enclave},
+		WithGeometryDatasets(square), WithProperties())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		name   string
+		r, arg *Rgeo
+	}{{"a.Merge(b)", a, b}, {"b.Merge(a)", b, a}} {
+		name := test.name
+		r, err := test.r.Merge(test.arg)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if _, err := r.GetGeometry(orb.Point{0.5, 0.5}, getFunctionName(squares)); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		if _, err := r.GetGeometry(orb.Point{0.5, 52.5}, getFunctionName(square)); err != nil {
+			t.Errorf("%s: %v", name, err)
+		}
+		if _, err := r.GetGeometry(orb.Point{2.5, 0.5}, getFunctionName(enclave)); err == nil {
+			t.Errorf("%s: expected error for dataset without geometry", name)
+		}
+
+		expected := []string{getFunctionName(square), getFunctionName(squares)}
+		sort.Strings(expected)
+		if diff := deep.Equal(expected, r.GeometryDatasets()); diff != nil {
+			t.Errorf("%s: %v", name, diff)
+		}
+
+		props, err := r.ReverseGeocodeProperties(orb.Point{0.5, 52.5})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if diff := deep.Equal(map[string]interface{}{"ISO_A3": "TST"}, props); diff != nil {
+			t.Errorf("%s: %v", name, diff)
+		}
+	}
+}
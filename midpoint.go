@@ -0,0 +1,63 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"math"
+
+	"github.com/golang/geo/s1"
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+// ErrAntipodal is returned by MidpointLocation for points on opposite sides of
+// the Earth, which have infinitely many great circle routes between them.
+var ErrAntipodal = errors.New("points are antipodal")
+
+// antipodalTolerance is how close to opposite two points have to be to count
+// as antipodal, about 6cm on the ground.
+const antipodalTolerance = s1.Angle(1e-8)
+
+// MidpointLocation returns the Location of the point halfway along the great
+// circle route between a and b, the shortest route between them on a sphere.
+// This isn't the same as averaging the coordinates, e.g. the midpoint of
+// London and Los Angeles is in northern Canada rather than the Atlantic, and
+// routes across the antimeridian don't end up on the other side of the world.
+//
+// If a and b are antipodal there isn't a single shortest route, so it returns
+// ErrAntipodal. Otherwise it returns ErrLocationNotFound if the midpoint isn't
+// in any shape, as with ReverseGeocode.
+func (r *Rgeo) MidpointLocation(a, b orb.Point) (Location, error) {
+	m, err := midpoint(a, b)
+	if err != nil {
+		return Location{}, err
+	}
+
+	return r.ReverseGeocode(m)
+}
+
+// midpoint returns the midpoint of the great circle route between a and b.
+func midpoint(a, b orb.Point) (orb.Point, error) {
+	pa, pb := pointFromCoord(a), pointFromCoord(b)
+	if pa.Distance(pb) > math.Pi-antipodalTolerance {
+		return orb.Point{}, ErrAntipodal
+	}
+
+	ll := s2.LatLngFromPoint(s2.Interpolate(0.5, pa, pb))
+
+	return orb.Point{ll.Lng.Degrees(), ll.Lat.Degrees()}, nil
+}
@@ -0,0 +1,83 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/paulmach/orb"
+)
+
+func TestMidpoint(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     orb.Point
+		expected orb.Point
+		err      error
+	}{
+		{"Same", orb.Point{10, 20}, orb.Point{10, 20}, orb.Point{10, 20}, nil},
+		{"Equator", orb.Point{0, 0}, orb.Point{90, 0}, orb.Point{45, 0}, nil},
+		{"Antimeridian", orb.Point{179, 0}, orb.Point{-179, 0}, orb.Point{180, 0}, nil},
+		{"OverPole", orb.Point{0, 80}, orb.Point{180, 80}, orb.Point{0, 90}, nil},
+		{"Antipodal", orb.Point{10, 20}, antipode(orb.Point{10, 20}), orb.Point{}, ErrAntipodal},
+		{"Poles", orb.Point{0, 90}, orb.Point{0, -90}, orb.Point{}, ErrAntipodal},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := midpoint(test.a, test.b)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error: %v\n got: %v\n", test.err, err)
+			}
+			if err != nil {
+				return
+			}
+
+			// The longitude doesn't matter at the poles, and 180 and -180 are
+			// the same.
+			if math.Abs(math.Abs(got[0])-math.Abs(test.expected[0])) > 1e-9 && math.Abs(got[1]) < 90-1e-9 {
+				t.Errorf("expected: %v, got: %v", test.expected, got)
+			}
+			if math.Abs(got[1]-test.expected[1]) > 1e-9 {
+				t.Errorf("expected: %v, got: %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestMidpointLocation(t *testing.T) {
+	r, err := New(Countries110)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Averaging the coordinates would give a point in the Atlantic.
+	loc, err := r.MidpointLocation(orb.Point{-0.13, 51.5}, orb.Point{-118.24, 34.05})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loc.Country != "Canada" {
+		t.Errorf("expected Canada, got: %s", loc.Country)
+	}
+
+	_, err = r.MidpointLocation(orb.Point{-0.13, 51.5}, antipode(orb.Point{-0.13, 51.5}))
+	if !errors.Is(err, ErrAntipodal) {
+		t.Errorf("expected error: %v\n got: %v\n", ErrAntipodal, err)
+	}
+}
@@ -0,0 +1,161 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"math"
+
+	"github.com/golang/geo/s1"
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+// earthRadiusMeters is the mean radius of the Earth, used to convert between
+// angles on the unit sphere and distances on the ground.
+const earthRadiusMeters = 6371008.8
+
+// NearestLocation returns the Location of the shape closest to the given
+// coordinate, along with the distance to it in meters. If the coordinate is
+// inside a shape the distance is 0.
+//
+// Distances are measured on a sphere with the Earth's mean radius of 6371008.8
+// meters, so expect errors of up to around 0.5% compared to the WGS84
+// ellipsoid.
+//
+// The search keeps going until it finds a shape, however far away, so for
+// points which are always near land ReverseGeocodeOrNearest is faster.
+func (r *Rgeo) NearestLocation(loc orb.Point) (Location, float64, error) {
+	shape, dist, ok := r.nearestShape(pointFromCoord(loc), s1.InfChordAngle())
+	if !ok {
+		return Location{}, 0, ErrLocationNotFound
+	}
+
+	return r.locs[shape], dist, nil
+}
+
+// NearestLocationInContinent works like NearestLocation, but only looks at
+// shapes with the given Continent, so a point in the sea between two
+// continents, or in a shape on another one, gets the nearest Location on the
+// one you want. A point inside a shape on the continent has a distance of 0.
+//
+// Shapes without a Continent, like those from Cities10, are never in scope.
+// If none of the loaded shapes are on the continent it returns
+// ErrLocationNotFound.
+func (r *Rgeo) NearestLocationInContinent(loc orb.Point, continent Continent) (Location, float64, error) {
+	return r.nearestLocationWhere(loc, func(l Location) bool {
+		return l.Continent == string(continent)
+	})
+}
+
+// NearestLocationInRegion works like NearestLocationInContinent, but for the
+// UN Region of the shapes rather than their Continent.
+func (r *Rgeo) NearestLocationInRegion(loc orb.Point, region Region) (Location, float64, error) {
+	return r.nearestLocationWhere(loc, func(l Location) bool {
+		return l.Region == string(region)
+	})
+}
+
+// nearestLocationWhere returns the Location of the shape closest to loc out of
+// those with a Location for which match returns true, and the distance to it
+// in meters.
+func (r *Rgeo) nearestLocationWhere(loc orb.Point, match func(Location) bool) (Location, float64, error) {
+	p := pointFromCoord(loc)
+
+	for _, shape := range r.containingShapes(p) {
+		if l := r.locs[shape]; match(l) {
+			return l, 0, nil
+		}
+	}
+
+	keep := func(id int32) bool { return match(r.locs[r.index.Shape(id)]) }
+
+	ref, dist, ok := r.edgeGrid().nearestEdge(p, s1.InfChordAngle(), keep)
+	if !ok {
+		return Location{}, 0, ErrLocationNotFound
+	}
+
+	return r.locs[r.index.Shape(ref.shape)], metersFromChordAngle(dist), nil
+}
+
+// ReverseGeocodeSnapped works like ReverseGeocode, but if the coordinate isn't
+// inside any shape it returns the Location of the nearest shape, as long as
+// that shape is no more than toleranceMeters away. This is useful for points
+// from low precision sources which land just off the coast. If there's no
+// shape within toleranceMeters either it returns ErrLocationNotFound, or the
+// Location from WithFallbackLocation.
+//
+// A toleranceMeters of 0 gives exactly the same result as ReverseGeocode.
+func (r *Rgeo) ReverseGeocodeSnapped(loc orb.Point, toleranceMeters float64) (Location, error) {
+	l, _, err := r.ReverseGeocodeOrNearest(loc, toleranceMeters)
+	return r.withFallback(l, err)
+}
+
+// ReverseGeocodeOrNearest returns the Location containing the given coordinate
+// with a distance of 0, or if there isn't one, the Location of the nearest shape
+// and the distance to it in meters, as long as it's within maxMeters. If there
+// is nothing within maxMeters it returns ErrLocationNotFound.
+//
+// The nearest shape is only searched for when the coordinate isn't contained in
+// any shape, so exact hits cost the same as ReverseGeocode. The search only
+// looks at edges within maxMeters, so it doesn't have to scan distant
+// continents for a point off the coast of another, and a point with nothing
+// within maxMeters gives ErrLocationNotFound without searching any further.
+func (r *Rgeo) ReverseGeocodeOrNearest(loc orb.Point, maxMeters float64) (Location, float64, error) {
+	l, err := r.reverseGeocode(pointFromCoord(loc))
+	if !errors.Is(err, ErrLocationNotFound) || maxMeters <= 0 {
+		return l, 0, err
+	}
+
+	shape, dist, ok := r.nearestShape(pointFromCoord(loc), chordAngleFromMeters(maxMeters))
+	if !ok {
+		return Location{}, 0, ErrLocationNotFound
+	}
+
+	return r.locs[shape], dist, nil
+}
+
+// nearestShape returns the shape closest to p and the distance to it in
+// meters, which is 0 if p is inside a shape. Only shapes within limit are
+// considered, ok is false if there are none.
+func (r *Rgeo) nearestShape(p s2.Point, limit s1.ChordAngle) (shape s2.Shape, meters float64, ok bool) {
+	if res := r.containingShapes(p); len(res) > 0 {
+		return res[0], 0, true
+	}
+
+	id, dist, ok := r.edgeGrid().nearest(p, limit)
+	if !ok {
+		return nil, 0, false
+	}
+
+	return r.index.Shape(id), metersFromChordAngle(dist), true
+}
+
+// chordAngleFromMeters converts a distance on the ground to a ChordAngle. The
+// result is rounded up to the next representable value so that limits are
+// inclusive.
+func chordAngleFromMeters(m float64) s1.ChordAngle {
+	if math.IsInf(m, 1) {
+		return s1.InfChordAngle()
+	}
+
+	return s1.ChordAngleFromAngle(s1.Angle(m / earthRadiusMeters)).Successor()
+}
+
+// metersFromChordAngle converts a ChordAngle to a distance on the ground.
+func metersFromChordAngle(c s1.ChordAngle) float64 {
+	return c.Angle().Radians() * earthRadiusMeters
+}
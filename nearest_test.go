@@ -0,0 +1,295 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/golang/geo/s1"
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+// squareGeo is a single 1x1 degree square, used as a tiny known world.
+const squareGeo = `{
+	"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"ISO_A3":"TST"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,52],[1,52],[1,53],[0,53],[0,52]]]}}
+		]
+	}`
+
+func TestNearestLocation(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, squareGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// One degree of latitude is about 111km, edges are great circles so
+	// distances to them are only roughly what they would be on a map.
+	testdata := []struct {
+		name     string
+		in       orb.Point
+		expected float64
+	}{
+		{name: "inside", in: orb.Point{0.5, 52.5}, expected: 0},
+		{name: "north", in: orb.Point{0.5, 54}, expected: 111195},
+		{name: "south", in: orb.Point{0.5, 51.9}, expected: 11119.5},
+	}
+
+	for _, test := range testdata {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			loc, dist, err := r.NearestLocation(test.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := deep.Equal(Location{CountryCode3: "TST"}, loc); diff != nil {
+				t.Error(diff)
+			}
+			if math.Abs(dist-test.expected) > test.expected*0.02+1 {
+				t.Errorf("expected distance: %f, got: %f", test.expected, dist)
+			}
+		})
+	}
+}
+
+func TestReverseGeocodeSnapped(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, squareGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testdata := []struct {
+		name      string
+		in        orb.Point
+		tolerance float64
+		err       error
+		expected  Location
+	}{
+		{
+			name:      "inside",
+			in:        orb.Point{0.5, 52.5},
+			tolerance: 0,
+			expected:  Location{CountryCode3: "TST"},
+		},
+		{
+			name:      "zero tolerance",
+			in:        orb.Point{0.5, 51.99},
+			tolerance: 0,
+			err:       ErrLocationNotFound,
+		},
+		{
+			name:      "within tolerance",
+			in:        orb.Point{0.5, 51.99},
+			tolerance: 2000,
+			expected:  Location{CountryCode3: "TST"},
+		},
+		{
+			name:      "beyond tolerance",
+			in:        orb.Point{0.5, 51.9},
+			tolerance: 2000,
+			err:       ErrLocationNotFound,
+		},
+	}
+
+	for _, test := range testdata {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			loc, err := r.ReverseGeocodeSnapped(test.in, test.tolerance)
+			if !errors.Is(err, test.err) {
+				t.Errorf("expected error: %s\n got: %s\n", test.err, err)
+			}
+			if diff := deep.Equal(test.expected, loc); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestReverseGeocodeOrNearest(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, squareGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loc, dist, err := r.ReverseGeocodeOrNearest(orb.Point{0.5, 52.5}, 1000)
+	if err != nil {
+		t.Error(err)
+	}
+	if dist != 0 {
+		t.Errorf("expected distance 0 for exact hit, got: %f", dist)
+	}
+	if diff := deep.Equal(Location{CountryCode3: "TST"}, loc); diff != nil {
+		t.Error(diff)
+	}
+
+	loc, dist, err = r.ReverseGeocodeOrNearest(orb.Point{0.5, 51.99}, 2000)
+	if err != nil {
+		t.Error(err)
+	}
+	if dist <= 0 || dist > 2000 {
+		t.Errorf("expected distance in (0, 2000], got: %f", dist)
+	}
+	if diff := deep.Equal(Location{CountryCode3: "TST"}, loc); diff != nil {
+		t.Error(diff)
+	}
+
+	_, _, err = r.ReverseGeocodeOrNearest(orb.Point{0.5, 51.9}, 2000)
+	if !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("expected error: %s\n got: %s\n", ErrLocationNotFound, err)
+	}
+}
+
+// TestNearestLocation_BruteForce checks NearestLocation against the distance to
+// every edge in Countries110, for random points all over the world.
+func TestNearestLocation_BruteForce(t *testing.T) {
+	r, err := New(Countries110)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	for i := 0; i < 100; i++ {
+		in := orb.Point{(rng.Float64() * 360) - 180, (rng.Float64() * 180) - 90}
+		p := pointFromCoord(in)
+		if len(r.containingShapes(p)) > 0 {
+			continue
+		}
+
+		expected := s1.InfChordAngle()
+		for id := int32(0); id < int32(r.index.Len()); id++ {
+			shape := r.index.Shape(id)
+			for e := 0; e < shape.NumEdges(); e++ {
+				edge := shape.Edge(e)
+				expected, _ = s2.UpdateMinDistance(p, edge.V0, edge.V1, expected)
+			}
+		}
+
+		_, dist, err := r.NearestLocation(in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := metersFromChordAngle(expected); math.Abs(dist-want) > 1e-6 {
+			t.Errorf("%v: expected distance: %f, got: %f", in, want, dist)
+		}
+	}
+}
+
+func BenchmarkNearestLocation_10(b *testing.B) {
+	r, err := New(Countries10)
+	if err != nil {
+		b.Error(err)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _, _ = r.NearestLocation(orb.Point{
+			(rand.Float64() * 360) - 180,
+			(rand.Float64() * 180) - 90,
+		})
+	}
+}
+
+func BenchmarkReverseGeocodeOrNearest_10(b *testing.B) {
+	r, err := New(Countries10)
+	if err != nil {
+		b.Error(err)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _, _ = r.ReverseGeocodeOrNearest(orb.Point{
+			(rand.Float64() * 360) - 180,
+			(rand.Float64() * 180) - 90,
+		}, 100000)
+	}
+}
+
+// continentsGeo has a square in Europe and one in Africa, with a gap between
+// them.
+const continentsGeo = `{
+	"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"ADMIN":"North","CONTINENT":"Europe","REGION_UN":"Europe"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,2],[1,2],[1,3],[0,3],[0,2]]]}},
+			{"type":"Feature",
+			"properties":{"ADMIN":"South","CONTINENT":"Africa","REGION_UN":"Africa"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}}
+		]
+	}`
+
+func TestNearestLocationInContinent(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, continentsGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	north := Location{Country: "North", Continent: "Europe", Region: "Europe", RegionCode: "150"}
+	south := Location{Country: "South", Continent: "Africa", Region: "Africa", RegionCode: "002"}
+
+	testdata := []struct {
+		name      string
+		in        orb.Point
+		continent Continent
+		expected  Location
+		meters    float64
+	}{
+		{name: "inside", in: orb.Point{0.5, 2.5}, continent: ContinentEurope, expected: north},
+		{name: "gap near south", in: orb.Point{0.5, 1.1}, continent: ContinentEurope, expected: north, meters: 100075},
+		{name: "gap near north", in: orb.Point{0.5, 1.9}, continent: ContinentAfrica, expected: south, meters: 100075},
+		{name: "inside other", in: orb.Point{0.5, 0.5}, continent: ContinentEurope, expected: north, meters: 166792},
+	}
+
+	for _, test := range testdata {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			loc, dist, err := r.NearestLocationInContinent(test.in, test.continent)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := deep.Equal(test.expected, loc); diff != nil {
+				t.Error(diff)
+			}
+			if math.Abs(dist-test.meters) > test.meters*0.01+1 {
+				t.Errorf("expected distance: %f, got: %f", test.meters, dist)
+			}
+		})
+	}
+
+	loc, _, err := r.NearestLocationInRegion(orb.Point{0.5, 1.9}, RegionAfrica)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(south, loc); diff != nil {
+		t.Error(diff)
+	}
+
+	if _, _, err := r.NearestLocationInContinent(orb.Point{0.5, 1.5}, ContinentAsia); !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("expected ErrLocationNotFound, got %v", err)
+	}
+}
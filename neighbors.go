@@ -0,0 +1,128 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+// Neighbors returns the Locations of the countries which share a border with
+// the country containing loc, e.g. France and Germany for a point in Belgium.
+// The country is the shape containing loc with the country fields and nothing
+// finer, as in CountryCovering, and only shapes like that count as neighbours,
+// so a country dataset like Countries10 has to be loaded. If there is no such
+// shape it returns ErrLocationNotFound, and a country without any neighbours,
+// like an island, gives an empty slice.
+//
+// Another country shares a border if it touches the country, meaning a vertex
+// of the country's border is within 1cm of its border, as they are along
+// shared borders within a dataset, or if the two overlap. So countries which
+// only touch at a corner count, but ones separated by a narrow strait don't.
+// Shapes with the same Country as the one containing loc, like its copy in
+// another dataset, are skipped. Duplicate Locations are only returned once, in
+// the order the shapes were loaded.
+//
+// Working out the neighbours of a country means going through all of its
+// vertices, which takes a while for big countries, so they're cached the first
+// time they're needed. The cache is cleared by AddFeature.
+func (r *Rgeo) Neighbors(loc orb.Point) ([]Location, error) {
+	country, ok := r.countryShape(pointFromCoord(loc))
+	if !ok {
+		return nil, ErrLocationNotFound
+	}
+
+	return r.uniqueLocations(r.neighbourShapes(country)), nil
+}
+
+// countryShape returns the first shape containing p which has the country
+// fields and nothing finer, in the order set by the dataset priorities and
+// ShapePreference.
+func (r *Rgeo) countryShape(p s2.Point) (*s2.Polygon, bool) {
+	for _, shape := range r.orderShapes(r.containingShapes(p)) {
+		if !isCountryShape(r.locs[shape]) {
+			continue
+		}
+
+		if p, ok := shape.(*s2.Polygon); ok {
+			return p, true
+		}
+	}
+
+	return nil, false
+}
+
+// isCountryShape reports whether a shape with the Location l is a country, with
+// a Country and no finer fields.
+func isCountryShape(l Location) bool {
+	return l.Country != "" && l.adminLevel() == LevelCountry
+}
+
+// neighbourShapes returns the country shapes which share a border with country,
+// in the order they were loaded, from the cache if they've been worked out
+// already.
+func (r *Rgeo) neighbourShapes(country *s2.Polygon) []s2.Shape {
+	r.neighboursMu.Lock()
+	defer r.neighboursMu.Unlock()
+
+	if shapes, ok := r.neighbours[country]; ok {
+		return shapes
+	}
+
+	name := r.locs[country].Country
+	isNeighbour := func(shape s2.Shape) bool {
+		l := r.locs[shape]
+		return shape != country && isCountryShape(l) && l.Country != name
+	}
+
+	found := make(map[s2.Shape]bool)
+
+	// Overlapping shapes, which might not touch at any vertex.
+	bound := country.RectBound()
+	for i := int32(0); i < int32(r.index.Len()); i++ {
+		p, ok := r.index.Shape(i).(*s2.Polygon)
+		if !ok || !isNeighbour(p) || !bound.Intersects(p.RectBound()) {
+			continue
+		}
+
+		if country.Intersects(p) {
+			found[p] = true
+		}
+	}
+
+	// Touching shapes, from the edges near each vertex.
+	grid := r.edgeGrid()
+	tolerance := chordAngleFromMeters(borderToleranceMeters)
+	for i := 0; i < country.NumLoops(); i++ {
+		l := country.Loop(i)
+		for j := 0; j < l.NumVertices(); j++ {
+			for _, id := range grid.within(l.Vertex(j), tolerance) {
+				if shape := r.index.Shape(id); !found[shape] && isNeighbour(shape) {
+					found[shape] = true
+				}
+			}
+		}
+	}
+
+	shapes := r.inLoadOrder(found)
+
+	if r.neighbours == nil {
+		r.neighbours = make(map[s2.Shape][]s2.Shape)
+	}
+	r.neighbours[country] = shapes
+
+	return shapes
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+func TestNeighbors(t *testing.T) {
+	// Corner only touches East at a corner, Disputed overlaps East without
+	// touching any of its vertices, and Westshire is a province of West.
+	countriesGeo := `{
+		"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"ADMIN":"West"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}},
+			{"type":"Feature",
+			"properties":{"ADMIN":"East"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[1,0],[2,0],[2,1],[1,1],[1,0]]]}},
+			{"type":"Feature",
+			"properties":{"ADMIN":"Corner"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[2,1],[3,1],[3,2],[2,2],[2,1]]]}},
+			{"type":"Feature",
+			"properties":{"ADMIN":"Island"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[5,0],[6,0],[6,1],[5,1],[5,0]]]}},
+			{"type":"Feature",
+			"properties":{"ADMIN":"Disputed"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[1.5,-0.5],[2.5,-0.5],[2.5,0.5],[1.5,0.5],[1.5,-0.5]]]}},
+			{"type":"Feature",
+			"properties":{"ADMIN":"West","name":"Westshire"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0.5,0],[1,0],[1,1],[0.5,1],[0.5,0]]]}}
+		]
+	}`
+
+	r, err := New(func() []byte { return compressData(t, countriesGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		in       orb.Point
+		expected []string
+		err      error
+	}{
+		{"West", orb.Point{0.2, 0.5}, []string{"East"}, nil},
+		{"East", orb.Point{1.2, 0.8}, []string{"West", "Corner", "Disputed"}, nil},
+		{"Corner", orb.Point{2.5, 1.5}, []string{"East"}, nil},
+		{"Island", orb.Point{5.5, 0.5}, nil, nil},
+		{"Sea", orb.Point{10, 10}, nil, ErrLocationNotFound},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			locs, err := r.Neighbors(test.in)
+			if !errors.Is(err, test.err) {
+				t.Errorf("expected error: %v\n got: %v\n", test.err, err)
+			}
+
+			var got []string
+			for _, l := range locs {
+				got = append(got, l.Country)
+			}
+			if diff := deep.Equal(test.expected, got); diff != nil {
+				t.Error(diff)
+			}
+
+			// The second time comes from the cache.
+			again, _ := r.Neighbors(test.in)
+			if diff := deep.Equal(locs, again); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"strings"
+)
+
+// Option sets optional behaviour for NewWithOptions and
+// NewFromFeatureCollection.
+type Option func(*options)
+
+// options holds everything that can be set with an Option.
+type options struct {
+	// filter reports whether a feature should be loaded, given its GeoJSON
+	// properties. nil loads everything.
+	filter func(properties map[string]interface{}) bool
+
+	cityPreference CityPreference
+
+	shapePreference ShapePreference
+
+	// priorities are the dataset priorities from WithDatasetPriority, by
+	// dataset name.
+	priorities map[string]int
+
+	// validity are the validity periods from WithDatasetValidity, by dataset
+	// name.
+	validity map[string]validity
+
+	// featureIDKeys are the GeoJSON properties read for the FeatureID, nil
+	// uses defaultFeatureIDKeys.
+	featureIDKeys []string
+
+	// geometryDatasets are the names of the datasets to keep the geometry of,
+	// from WithGeometryDatasets. nil keeps all of them.
+	geometryDatasets map[string]bool
+
+	// eezMeters is the distance from WithEEZDistance, only used if
+	// eezMetersSet is true, otherwise it's defaultEEZMeters.
+	eezMeters    float64
+	eezMetersSet bool
+
+	// eezDataset is the name of the dataset from WithEEZDataset.
+	eezDataset string
+
+	// snapMeters is the tolerance from WithCoarserSnapping, 0 turns it off.
+	snapMeters float64
+
+	// countryMetadata sets the CallingCode and CurrencyCode of a Location,
+	// from WithCountryMetadata.
+	countryMetadata func(Location) Location
+
+	// concurrent loads the datasets concurrently, from WithConcurrentLoading.
+	concurrent bool
+
+	// dedupCountries skips country shapes covered by finer ones, from
+	// DedupCountries.
+	dedupCountries bool
+
+	// altitudeKeys are the GeoJSON properties holding the minimum and maximum
+	// altitude of each shape, from WithAltitudeProperties.
+	altitudeKeys []string
+
+	// progress is the callback from WithProgress.
+	progress func(dataset string, done, total int)
+
+	// changed is called with the name of each dataset changed by AddFeature,
+	// from WithDatasetChangeHook.
+	changed func(dataset string)
+
+	// fallback is returned by ReverseGeocode instead of ErrLocationNotFound,
+	// from WithFallbackLocation.
+	fallback *Location
+
+	// language is the language of the names from WithLanguage, "" keeps the
+	// English ones.
+	language string
+
+	// maxShapes is the most containing shapes combined into a Location, from
+	// WithMaxShapes, 0 combines all of them.
+	maxShapes int
+
+	// fields are the Location fields to keep, 0 keeps all of them.
+	fields Field
+
+	// properties keeps the GeoJSON properties of each feature.
+	properties bool
+
+	// rejectDegenerate makes rings with no area an error rather than being
+	// skipped.
+	rejectDegenerate bool
+
+	// rejectMissingGeometry makes features with a null geometry an error
+	// rather than being skipped.
+	rejectMissingGeometry bool
+
+	// rfc7946Winding makes rings which aren't wound as in RFC 7946 an error
+	// rather than being normalised.
+	rfc7946Winding bool
+}
+
+// WithFeatureFilter only loads the features for which keep returns true, keep
+// is given the GeoJSON properties of each feature. If it is used more than once
+// a feature has to be kept by all of the filters to be loaded.
+func WithFeatureFilter(keep func(properties map[string]interface{}) bool) Option {
+	return func(o *options) {
+		if prev := o.filter; prev != nil {
+			o.filter = func(p map[string]interface{}) bool { return prev(p) && keep(p) }
+			return
+		}
+
+		o.filter = keep
+	}
+}
+
+// ExcludeDisputed skips features for territories whose sovereignty is disputed,
+// so that only one of the claims is ever returned. A feature is counted as
+// disputed if its Natural Earth NOTE_BRK property contains "Claimed by" (e.g.
+// "Admin. by U.K.; Claimed by Argentina" for the Falkland Islands).
+//
+// By default all features are loaded. Use WithFeatureFilter for any other
+// worldview, for example filtering on SOVEREIGNT.
+func ExcludeDisputed() Option {
+	return WithFeatureFilter(func(p map[string]interface{}) bool {
+		return !strings.Contains(getPropertyString(p, "NOTE_BRK"), "Claimed by")
+	})
+}
+
+// RejectDegenerateRings makes loading fail with an error wrapping
+// ErrDegenerateRing if any polygon has a ring with no area, such as one where
+// all of the points are in a line. By default these rings are skipped, as
+// they can't be indexed correctly. The included datasets don't have any.
+func RejectDegenerateRings() Option {
+	return func(o *options) {
+		o.rejectDegenerate = true
+	}
+}
+
+// RejectMissingGeometry makes loading fail with ErrMissingGeometry if any
+// feature has a null geometry. By default these features are skipped, as some
+// datasets have features which only hold metadata. Features with a geometry
+// which isn't a Polygon or MultiPolygon always make loading fail.
+func RejectMissingGeometry() Option {
+	return func(o *options) {
+		o.rejectMissingGeometry = true
+	}
+}
+
+// RequireRFC7946Winding makes loading fail with an error wrapping
+// ErrWrongWinding if any polygon doesn't follow the winding order from RFC
+// 7946, which is counterclockwise for exterior rings and clockwise for holes.
+// By default rings of either winding are accepted, and normalised before being
+// indexed. This is for validating custom datasets, the included Natural Earth
+// datasets have clockwise exterior rings so they fail with it.
+func RequireRFC7946Winding() Option {
+	return func(o *options) {
+		o.rfc7946Winding = true
+	}
+}
+
+// WithFallbackLocation makes ReverseGeocode return loc, with a nil error,
+// rather than ErrLocationNotFound for coordinates which aren't in any shape,
+// e.g. a Location with a Country of "UNKNOWN" for bulk processing where points
+// at sea are expected. Other errors are still returned. By default
+// ErrLocationNotFound is returned.
+//
+// It also applies to ReverseGeocodeSnapped, when there's no shape within the
+// tolerance either, ReverseGeocodeVM, ReverseGeocodeWKB,
+// ReverseGeocodeProjected, MidpointLocation and Antipode, along with Pool and
+// ReverseGeocodeSeq. Every other method still returns ErrLocationNotFound,
+// including the ones which filter the shapes first, like ReverseGeocodeAt,
+// and the ones which need to tell misses apart, like ReverseGeocodeOrNearest
+// and Segments.
+func WithFallbackLocation(loc Location) Option {
+	return func(o *options) {
+		o.fallback = &loc
+	}
+}
+
+// WithMaxShapes limits how many of the shapes containing a coordinate are
+// combined into its Location to n, as a guard against data with many
+// overlapping shapes, where every query would go through all of them. The
+// shapes are put in order, by WithDatasetPriority and WithShapePreference,
+// before the rest are dropped, so the preferred ones are always used.
+//
+// The dropped shapes don't fill in any fields, so capping can leave out
+// fields, like the Province, that only they would have given. An n of 0 or
+// less, the default, combines all of them.
+func WithMaxShapes(n int) Option {
+	return func(o *options) {
+		if n < 0 {
+			n = 0
+		}
+		o.maxShapes = n
+	}
+}
+
+// WithFeatureIDProperty sets the GeoJSON properties the Location FeatureID is
+// read from, the first one that is set on a feature is used. Strings are used
+// as they are and numbers are formatted as decimals. Features without any of
+// them get an empty FeatureID.
+//
+// By default NE_ID and ne_id are used, which are set on every feature in the
+// included datasets apart from Cities10. Use it with no keys to leave the
+// FeatureID empty.
+func WithFeatureIDProperty(keys ...string) Option {
+	return func(o *options) {
+		o.featureIDKeys = append([]string{}, keys...)
+	}
+}
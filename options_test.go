@@ -0,0 +1,246 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+// disputedGeo has a mainland square and a disputed island next to it.
+const disputedGeo = `{
+	"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"ADMIN":"Mainland"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,0],[2,0],[2,2],[0,2],[0,0]]]}},
+			{"type":"Feature",
+			"properties":{"ADMIN":"Island","NOTE_BRK":"Admin. by Island; Claimed by Mainland"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[3,0],[4,0],[4,1],[3,1],[3,0]]]}}
+		]
+	}`
+
+func TestExcludeDisputed(t *testing.T) {
+	dataset := func() []byte { return compressData(t, disputedGeo) }
+
+	all, err := New(dataset)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loc, err := all.ReverseGeocode(orb.Point{3.5, 0.5})
+	if err != nil {
+		t.Error(err)
+	}
+	if diff := deep.Equal(Location{Country: "Island"}, loc); diff != nil {
+		t.Error(diff)
+	}
+
+	excl, err := NewWithOptions([]func() []byte{dataset}, ExcludeDisputed())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := excl.ReverseGeocode(orb.Point{3.5, 0.5}); !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("expected error: %s\n got: %s\n", ErrLocationNotFound, err)
+	}
+
+	loc, err = excl.ReverseGeocode(orb.Point{1, 1})
+	if err != nil {
+		t.Error(err)
+	}
+	if diff := deep.Equal(Location{Country: "Mainland"}, loc); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestWithFeatureFilter(t *testing.T) {
+	dataset := func() []byte { return compressData(t, disputedGeo) }
+
+	r, err := NewWithOptions([]func() []byte{dataset},
+		ExcludeDisputed(),
+		WithFeatureFilter(func(p map[string]interface{}) bool { return p["ADMIN"] != "Mainland" }),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if n := len(r.locs); n != 0 {
+		t.Errorf("expected no features, got %d", n)
+	}
+}
+
+func TestWithFeatureIDProperty(t *testing.T) {
+	idGeo := `{
+		"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"ADMIN":"Land","NE_ID":101,"gid":"land-1"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,0],[4,0],[4,4],[0,4],[0,0]]]}},
+			{"type":"Feature",
+			"properties":{"name":"North","NE_ID":202},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,2],[4,2],[4,4],[0,4],[0,2]]]}}
+		]
+	}`
+	dataset := func() []byte { return compressData(t, idGeo) }
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		in       orb.Point
+		expected string
+	}{
+		{"Default", nil, orb.Point{1, 1}, "101"},
+		{"MostSpecific", nil, orb.Point{1, 3}, "202"},
+		{"Custom", []Option{WithFeatureIDProperty("gid")}, orb.Point{1, 1}, "land-1"},
+		{"CustomMissing", []Option{WithFeatureIDProperty("gid")}, orb.Point{1, 3}, "land-1"},
+		{"None", []Option{WithFeatureIDProperty()}, orb.Point{1, 3}, ""},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			r, err := NewWithOptions([]func() []byte{dataset}, test.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			loc, err := r.ReverseGeocode(test.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if loc.FeatureID != test.expected {
+				t.Errorf("expected: %q, got: %q", test.expected, loc.FeatureID)
+			}
+		})
+	}
+}
+
+func TestWithFallbackLocation(t *testing.T) {
+	squares := func() []byte { return compressData(t, twoSquaresGeo) }
+	unknown := Location{Country: "UNKNOWN"}
+
+	r, err := NewWithOptions([]func() []byte{squares}, WithFallbackLocation(unknown))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loc, err := r.ReverseGeocode(orb.Point{0.5, 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(Location{Country: "West", CountryCode3: "WST"}, loc); diff != nil {
+		t.Error(diff)
+	}
+
+	loc, err = r.ReverseGeocode(orb.Point{5, 5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(unknown, loc); diff != nil {
+		t.Error(diff)
+	}
+
+	// Methods which work like ReverseGeocode get it too.
+	if loc, err := r.ReverseGeocodeSnapped(orb.Point{5, 5}, 0); err != nil || loc != unknown {
+		t.Errorf("ReverseGeocodeSnapped: expected %v, got: %v, %v", unknown, loc, err)
+	}
+	if loc, err := r.ReverseGeocodeSnapped(orb.Point{5, 5}, 1000); err != nil || loc != unknown {
+		t.Errorf("ReverseGeocodeSnapped: expected %v, got: %v, %v", unknown, loc, err)
+	}
+	if loc, err := r.ReverseGeocodeVM(orb.Point{5, 5}, s2.VertexModelClosed); err != nil || loc != unknown {
+		t.Errorf("ReverseGeocodeVM: expected %v, got: %v, %v", unknown, loc, err)
+	}
+
+	// Methods which need to tell misses apart still get them.
+	if _, _, err := r.ReverseGeocodeOrNearest(orb.Point{5, 5}, 1000); !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("expected error: %v\n got: %v\n", ErrLocationNotFound, err)
+	}
+	if loc, _, err := r.ReverseGeocodeOrNearest(orb.Point{1.005, 0.5}, 1000); err != nil || loc.Country != "West" {
+		t.Errorf("expected West, got: %v, %v", loc, err)
+	}
+
+	// Without it the error is returned.
+	plain, err := New(squares)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := plain.ReverseGeocode(orb.Point{5, 5}); !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("expected error: %v\n got: %v\n", ErrLocationNotFound, err)
+	}
+}
+
+func TestWithMaxShapes(t *testing.T) {
+	levels := []func() []byte{func() []byte { return compressData(t, levelsGeo) }}
+	town := orb.Point{1.5, 3.5}
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		expected Location
+	}{
+		{
+			name:     "Unlimited",
+			expected: Location{Country: "Land", CountryCode3: "TWN", Province: "North", ProvinceCode: "LND-N", City: "Town"},
+		},
+		{
+			name:     "Two",
+			opts:     []Option{WithMaxShapes(2)},
+			expected: Location{CountryCode3: "TWN", Province: "North", ProvinceCode: "LND-N", City: "Town"},
+		},
+		{
+			name:     "One",
+			opts:     []Option{WithMaxShapes(1)},
+			expected: Location{CountryCode3: "TWN", City: "Town"},
+		},
+		{
+			name:     "Preferred",
+			opts:     []Option{WithMaxShapes(1), WithShapePreference(ShapeLargest)},
+			expected: Location{Country: "Land", CountryCode3: "LND"},
+		},
+		{
+			name:     "Zero",
+			opts:     []Option{WithMaxShapes(0)},
+			expected: Location{Country: "Land", CountryCode3: "TWN", Province: "North", ProvinceCode: "LND-N", City: "Town"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			r, err := NewWithOptions(levels, test.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			loc, err := r.ReverseGeocode(town)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := deep.Equal(test.expected, loc); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,171 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"sort"
+
+	"github.com/golang/geo/s2"
+)
+
+// ShapePreference decides which shape's fields take priority when a coordinate
+// is inside more than one shape, such as an enclave drawn on top of the
+// country around it.
+type ShapePreference int
+
+const (
+	// ShapeFirst gives priority to the shapes in the order they were loaded.
+	// This is the default.
+	ShapeFirst ShapePreference = iota
+
+	// ShapeSmallest gives priority to the smallest shape by area, i.e. the
+	// most specific.
+	ShapeSmallest
+
+	// ShapeLargest gives priority to the largest shape by area, i.e. the most
+	// general.
+	ShapeLargest
+)
+
+// WithShapePreference sets how to pick between overlapping shapes. Every
+// containing shape still contributes to the Location, the fields of the
+// preferred shape are used first and the others only fill in the fields it
+// leaves empty, so e.g. a province shape still gives the Province when the
+// larger country shape around it is preferred. Ties keep the ShapeFirst order.
+//
+// The area of each shape is worked out when it's loaded, which costs a little
+// time and memory, so this should only be used with data that overlaps.
+func WithShapePreference(pref ShapePreference) Option {
+	return func(o *options) {
+		o.shapePreference = pref
+	}
+}
+
+// WithDatasetPriority sets the priority of a dataset passed to NewWithOptions,
+// for when datasets overlap. The fields of shapes from datasets with a higher
+// priority are used first, and lower priorities only fill in the fields they
+// leave empty, e.g. giving Cities10 a priority of 1 means its names win over
+// any City set in the other datasets. The priority covers all of the fields,
+// so datasets should only set the fields they're trusted for. Datasets without
+// a priority have a priority of 0, so by default the fields are taken in the
+// order the datasets were loaded.
+//
+// Within a priority, overlapping shapes are ordered by the ShapePreference.
+func WithDatasetPriority(dataset func() []byte, priority int) Option {
+	return func(o *options) {
+		if o.priorities == nil {
+			o.priorities = make(map[string]int)
+		}
+
+		o.priorities[getFunctionName(dataset)] = priority
+	}
+}
+
+// setDatasetOptions records the options set for the named dataset against a
+// shape loaded from it.
+func (r *Rgeo) setDatasetOptions(shape s2.Shape, dataset string) {
+	if priority := r.opts.priorities[dataset]; priority != 0 {
+		r.priorities[shape] = priority
+	}
+
+	if v, ok := r.opts.validity[dataset]; ok {
+		r.validity[shape] = v
+	}
+}
+
+// orderShapes returns the shapes sorted by their dataset priority and then the
+// ShapePreference, without changing s.
+func (r *Rgeo) orderShapes(s []s2.Shape) []s2.Shape {
+	if (r.opts.shapePreference == ShapeFirst && len(r.priorities) == 0) || len(s) < 2 {
+		return s
+	}
+
+	sorted := make([]s2.Shape, len(s))
+	copy(sorted, s)
+
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if pi, pj := r.priorities[sorted[i]], r.priorities[sorted[j]]; pi != pj {
+			return pi > pj
+		}
+
+		switch r.opts.shapePreference {
+		case ShapeFirst:
+			return false
+		case ShapeLargest:
+			return r.areas[sorted[i]] > r.areas[sorted[j]]
+		default:
+			return r.areas[sorted[i]] < r.areas[sorted[j]]
+		}
+	})
+
+	return sorted
+}
+
+// OverlapReport is a pair of shapes found by Overlaps.
+type OverlapReport struct {
+	// A and B are the Locations of the two shapes, A is the one loaded first.
+	A, B Location
+
+	// ShapeA and ShapeB are the shapes in the index returned by ShapeIndex,
+	// they must be treated as read only.
+	ShapeA, ShapeB s2.Shape
+}
+
+// Overlaps returns every pair of shapes from the given dataset whose interiors
+// intersect, for checking data: a point in both of them gets the fields of
+// whichever comes first in the order set by WithShapePreference, which might
+// not be the one expected. Shapes which only share a border don't overlap. The
+// pairs are in the order the shapes were loaded, and a dataset without any
+// gives an empty slice.
+//
+// It compares every pair of shapes whose bounding rectangles intersect, so it
+// is slow for big datasets, e.g. a few seconds for Provinces10, and is limited
+// to one dataset at a time. It's not needed for any queries.
+func (r *Rgeo) Overlaps(dataset string) ([]OverlapReport, error) {
+	shapes, err := r.DatasetShapes(dataset)
+	if err != nil {
+		return nil, err
+	}
+
+	bounds := make([]s2.Rect, len(shapes))
+	for i, shape := range shapes {
+		if p, ok := shape.(*s2.Polygon); ok {
+			bounds[i] = p.RectBound()
+		} else {
+			bounds[i] = s2.EmptyRect()
+		}
+	}
+
+	ret := []OverlapReport{}
+	for i, a := range shapes {
+		for j := i + 1; j < len(shapes); j++ {
+			if !bounds[i].Intersects(bounds[j]) {
+				continue
+			}
+
+			b := shapes[j]
+			pa, okA := a.(*s2.Polygon)
+			pb, okB := b.(*s2.Polygon)
+			if !okA || !okB || !pa.Intersects(pb) {
+				continue
+			}
+
+			ret = append(ret, OverlapReport{A: r.locs[a], B: r.locs[b], ShapeA: a, ShapeB: b})
+		}
+	}
+
+	return ret, nil
+}
@@ -0,0 +1,186 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+// enclaveGeo has a small enclave drawn on top of a bigger country, rather than
+// as a hole in it.
+const enclaveGeo = `{
+	"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"ADMIN":"Outer","CONTINENT":"Europe"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,0],[4,0],[4,4],[0,4],[0,0]]]}},
+			{"type":"Feature",
+			"properties":{"ADMIN":"Enclave"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[1,1],[2,1],[2,2],[1,2],[1,1]]]}}
+		]
+	}`
+
+func TestWithShapePreference(t *testing.T) {
+	dataset := func() []byte { return compressData(t, enclaveGeo) }
+
+	tests := []struct {
+		name     string
+		pref     ShapePreference
+		expected Location
+	}{
+		{"first", ShapeFirst, Location{Country: "Outer", Continent: "Europe"}},
+		{"smallest", ShapeSmallest, Location{Country: "Enclave", Continent: "Europe"}},
+		{"largest", ShapeLargest, Location{Country: "Outer", Continent: "Europe"}},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			r, err := NewWithOptions([]func() []byte{dataset}, WithShapePreference(test.pref))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			loc, err := r.ReverseGeocode(orb.Point{1.5, 1.5})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := deep.Equal(test.expected, loc); diff != nil {
+				t.Error(diff)
+			}
+
+			// Outside the enclave there is only one choice.
+			loc, err = r.ReverseGeocode(orb.Point{3, 3})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if loc.Country != "Outer" {
+				t.Errorf("expected: Outer, got: %s", loc.Country)
+			}
+		})
+	}
+}
+
+func TestWithDatasetPriority(t *testing.T) {
+	// The city shape has a stale country name. Giving the countries priority
+	// fixes that, but their City wins too, as the priority is for all fields.
+	cityGeo := `{
+		"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"ADMIN":"Old Outer","name_conve":"Town"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[1,1],[2,1],[2,2],[1,2],[1,1]]]}}
+		]
+	}`
+	countryGeo := `{
+		"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"ADMIN":"Outer","name_conve":"Countryside"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,0],[4,0],[4,4],[0,4],[0,0]]]}}
+		]
+	}`
+
+	cities := func() []byte { return compressData(t, cityGeo) }
+	countries := func() []byte { return compressData(t, countryGeo) }
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		expected Location
+	}{
+		{
+			name:     "load order",
+			expected: Location{Country: "Old Outer", City: "Town"},
+		},
+		{
+			name:     "countries first",
+			opts:     []Option{WithDatasetPriority(countries, 1)},
+			expected: Location{Country: "Outer", City: "Countryside"},
+		},
+		{
+			name:     "negative",
+			opts:     []Option{WithDatasetPriority(cities, -1)},
+			expected: Location{Country: "Outer", City: "Countryside"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			r, err := NewWithOptions([]func() []byte{cities, countries}, test.opts...)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			loc, err := r.ReverseGeocode(orb.Point{1.5, 1.5})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := deep.Equal(test.expected, loc); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
+func TestOverlaps(t *testing.T) {
+	enclave := func() []byte { return compressData(t, enclaveGeo) }
+	neighbours := func() []byte { return compressData(t, neighboursGeo) }
+
+	r, err := New(enclave, neighbours)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	overlaps, err := r.Overlaps(getFunctionName(enclave))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(overlaps) != 1 {
+		t.Fatalf("expected 1 overlap, got %d", len(overlaps))
+	}
+
+	o := overlaps[0]
+	expected := [2]Location{{Country: "Outer", Continent: "Europe"}, {Country: "Enclave"}}
+	if diff := deep.Equal(expected, [2]Location{o.A, o.B}); diff != nil {
+		t.Error(diff)
+	}
+	if loc, _ := r.ShapeLocation(o.ShapeB); loc != o.B {
+		t.Errorf("expected ShapeB to have Location %v, got %v", o.B, loc)
+	}
+
+	// Shapes which only share a border don't overlap, and neither do shapes
+	// from different datasets.
+	overlaps, err = r.Overlaps(getFunctionName(neighbours))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(overlaps) != 0 {
+		t.Errorf("expected no overlaps, got %v", overlaps)
+	}
+
+	if _, err := r.Overlaps("other"); err == nil {
+		t.Error("expected error for unknown dataset")
+	}
+}
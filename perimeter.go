@@ -0,0 +1,50 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"github.com/golang/geo/s1"
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+// Perimeter returns the total length in meters of the boundary of the shape
+// from the given dataset which contains the coordinate, e.g. for the length of
+// a country's borders and coastline. For MultiPolygons the boundaries of all
+// of the polygons are added up, and the boundaries of any holes are included.
+//
+// Edges are great circle arcs between the vertices, measured on a sphere with
+// the Earth's mean radius of 6371008.8 meters like NearestLocation. The result
+// is only as detailed as the dataset, coastlines especially come out much
+// shorter with Countries110 than with Countries10.
+func (r *Rgeo) Perimeter(loc orb.Point, dataset string) (float64, error) {
+	shape, _, err := r.datasetShape(loc, dataset)
+	if err != nil {
+		return 0, err
+	}
+
+	var length s1.Angle
+	if p, ok := shape.(*s2.Polygon); ok {
+		for _, l := range p.Loops() {
+			n := l.NumVertices()
+			for i := 0; i < n; i++ {
+				length += l.Vertex(i).Distance(l.Vertex(i + 1))
+			}
+		}
+	}
+
+	return length.Radians() * earthRadiusMeters, nil
+}
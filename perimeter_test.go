@@ -0,0 +1,78 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/geojson"
+)
+
+func TestPerimeter(t *testing.T) {
+	// A degree along the equator or a meridian is about 111.2km, so a 1x1
+	// degree square by the equator is about 444.8km around.
+	square := orb.Ring{{0, 0}, {1, 0}, {1, 1}, {0, 1}, {0, 0}}
+	bigSquare := orb.Ring{{10, 0}, {12, 0}, {12, 2}, {10, 2}, {10, 0}}
+	hole := orb.Ring{{10.5, 0.5}, {10.5, 1.5}, {11.5, 1.5}, {11.5, 0.5}, {10.5, 0.5}}
+
+	tests := []struct {
+		name     string
+		geometry orb.Geometry
+		in       orb.Point
+		expected float64
+	}{
+		{"Polygon", orb.Polygon{square}, orb.Point{0.5, 0.5}, 444770},
+		{"MultiPolygon", orb.MultiPolygon{{square}, {bigSquare}}, orb.Point{10.1, 0.1}, 444770 + 889470},
+		{"Hole", orb.Polygon{bigSquare, hole}, orb.Point{10.1, 0.1}, 889470 + 444730},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			fc := geojson.NewFeatureCollection()
+			fc.Append(geojson.NewFeature(test.geometry))
+
+			r, err := NewFromFeatureCollection(fc, "test")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := r.Perimeter(test.in, "test")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if math.Abs(got-test.expected) > test.expected*0.001 {
+				t.Errorf("expected: %.0f, got: %.0f", test.expected, got)
+			}
+		})
+	}
+
+	fc := geojson.NewFeatureCollection()
+	fc.Append(geojson.NewFeature(orb.Polygon{square}))
+	r, err := NewFromFeatureCollection(fc, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Perimeter(orb.Point{5, 5}, "test"); !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("expected error: %s\n got: %v\n", ErrLocationNotFound, err)
+	}
+	if _, err := r.Perimeter(orb.Point{0.5, 0.5}, "other"); err == nil {
+		t.Error("expected error for unknown dataset")
+	}
+}
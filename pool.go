@@ -0,0 +1,126 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/paulmach/orb"
+)
+
+// ErrPoolClosed is returned for queries submitted to a Pool after Close.
+var ErrPoolClosed = errors.New("pool closed")
+
+// Result is the outcome of a query submitted to a Pool.
+type Result struct {
+	Location Location
+	Err      error
+}
+
+// Pool runs ReverseGeocode queries on a fixed number of worker goroutines,
+// with a queue of the same size in front of them.
+//
+// The Rgeo methods are already safe for concurrent use, the index lookup is
+// done under a package level lock, so a Pool isn't needed for safety and more
+// workers won't make the lookups themselves run in parallel. What a Pool adds
+// is backpressure: at most 2*workers queries are in flight at once and Submit
+// blocks once the queue is full, rather than a burst of requests each starting
+// a goroutine which waits on the lock.
+type Pool struct {
+	r    *Rgeo
+	jobs chan poolJob
+	wg   sync.WaitGroup
+
+	// mu guards closed, and is held for reading while sending on jobs so that
+	// Close can't close it during a send.
+	mu     sync.RWMutex
+	closed bool
+}
+
+type poolJob struct {
+	loc orb.Point
+	res chan<- Result
+}
+
+// NewPool starts a Pool with the given number of workers querying r. If workers
+// is less than 1 one worker is used. The Pool should be closed with Close once
+// it is no longer needed.
+func NewPool(r *Rgeo, workers int) *Pool {
+	if workers < 1 {
+		workers = 1
+	}
+
+	p := &Pool{
+		r:    r,
+		jobs: make(chan poolJob, workers),
+	}
+
+	p.wg.Add(workers)
+	for i := 0; i < workers; i++ {
+		go p.work()
+	}
+
+	return p
+}
+
+func (p *Pool) work() {
+	defer p.wg.Done()
+
+	for j := range p.jobs {
+		loc, err := p.r.ReverseGeocode(j.loc)
+		j.res <- Result{Location: loc, Err: err}
+	}
+}
+
+// Submit queues a ReverseGeocode of loc and returns a channel which receives
+// the Result once it's done. It blocks while the queue is full. After Close the
+// Result has Err set to ErrPoolClosed.
+func (p *Pool) Submit(loc orb.Point) <-chan Result {
+	res := make(chan Result, 1)
+
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	if p.closed {
+		res <- Result{Err: ErrPoolClosed}
+		return res
+	}
+
+	p.jobs <- poolJob{loc: loc, res: res}
+
+	return res
+}
+
+// Query submits loc and waits for the Result, it works like ReverseGeocode but
+// goes through the Pool's queue.
+func (p *Pool) Query(loc orb.Point) (Location, error) {
+	res := <-p.Submit(loc)
+	return res.Location, res.Err
+}
+
+// Close stops accepting queries and waits for the queued ones to finish. It is
+// safe to call more than once.
+func (p *Pool) Close() {
+	p.mu.Lock()
+	if !p.closed {
+		p.closed = true
+		close(p.jobs)
+	}
+	p.mu.Unlock()
+
+	p.wg.Wait()
+}
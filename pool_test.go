@@ -0,0 +1,63 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+func TestPool(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, twoSquaresGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	p := NewPool(r, 2)
+
+	points := []orb.Point{{0.5, 0.5}, {1.5, 0.5}, {2.5, 0.5}}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		for _, pt := range points {
+			wg.Add(1)
+			go func(pt orb.Point) {
+				defer wg.Done()
+
+				expected, expectedErr := r.ReverseGeocode(pt)
+				got, err := p.Query(pt)
+				if !errors.Is(err, expectedErr) {
+					t.Errorf("%v: expected error: %v\n got: %v\n", pt, expectedErr, err)
+				}
+				if diff := deep.Equal(expected, got); diff != nil {
+					t.Errorf("%v: %v", pt, diff)
+				}
+			}(pt)
+		}
+	}
+	wg.Wait()
+
+	p.Close()
+	p.Close()
+
+	if _, err := p.Query(points[0]); !errors.Is(err, ErrPoolClosed) {
+		t.Errorf("expected error: %v\n got: %v\n", ErrPoolClosed, err)
+	}
+}
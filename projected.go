@@ -0,0 +1,48 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"fmt"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/project"
+)
+
+// The spatial reference system IDs supported by ReverseGeocodeProjected.
+const (
+	// SRIDWGS84 is longitude and latitude in degrees, as taken by
+	// ReverseGeocode.
+	SRIDWGS84 = 4326
+
+	// SRIDWebMercator is the spherical Mercator projection used by most web
+	// maps, in meters.
+	SRIDWebMercator = 3857
+)
+
+// ReverseGeocodeProjected works like ReverseGeocode, but takes the coordinate in
+// the given spatial reference system, which has to be SRIDWGS84 (EPSG:4326) or
+// SRIDWebMercator (EPSG:3857). Any other SRID gives an error.
+func (r *Rgeo) ReverseGeocodeProjected(x, y float64, srid int) (Location, error) {
+	switch srid {
+	case SRIDWGS84:
+		return r.ReverseGeocode(orb.Point{x, y})
+	case SRIDWebMercator:
+		return r.ReverseGeocode(project.Mercator.ToWGS84(orb.Point{x, y}))
+	}
+
+	return Location{}, fmt.Errorf("unsupported SRID %d, needs %d or %d", srid, SRIDWGS84, SRIDWebMercator)
+}
@@ -0,0 +1,81 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+)
+
+func TestReverseGeocodeProjected(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, squareGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		x, y     float64
+		srid     int
+		err      error
+		expected Location
+	}{
+		{
+			name:     "WGS84",
+			x:        0.5,
+			y:        52.5,
+			srid:     SRIDWGS84,
+			expected: Location{CountryCode3: "TST"},
+		},
+		{
+			// (0.5, 52.5) in Web Mercator.
+			name:     "Web Mercator",
+			x:        55659.75,
+			y:        6891041.76,
+			srid:     SRIDWebMercator,
+			expected: Location{CountryCode3: "TST"},
+		},
+		{
+			// Read as WGS84 this would be in the square.
+			name: "Web Mercator outside",
+			x:    0.5,
+			y:    52.5,
+			srid: SRIDWebMercator,
+			err:  ErrLocationNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			loc, err := r.ReverseGeocodeProjected(test.x, test.y, test.srid)
+			if !errors.Is(err, test.err) {
+				t.Errorf("expected error: %v\n got: %v\n", test.err, err)
+			}
+			if diff := deep.Equal(test.expected, loc); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+
+	_, err = r.ReverseGeocodeProjected(0.5, 52.5, 27700)
+	expected := "unsupported SRID 27700, needs 4326 or 3857"
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected error: %s\n got: %v\n", expected, err)
+	}
+}
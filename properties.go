@@ -0,0 +1,66 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+
+	"github.com/paulmach/orb"
+)
+
+// ErrNoProperties is returned by ReverseGeocodeProperties when the Rgeo wasn't
+// created with the WithProperties option.
+var ErrNoProperties = errors.New("properties not retained, use the WithProperties option")
+
+// WithProperties keeps the GeoJSON properties of every loaded feature, for use
+// with ReverseGeocodeProperties.
+//
+// This costs a lot more memory than the Location fields alone, as the Natural
+// Earth datasets have around a hundred properties per feature. Keeping them
+// adds around 4MB to the heap for Countries10 and 60MB for Provinces10.
+func WithProperties() Option {
+	return func(o *options) {
+		o.properties = true
+	}
+}
+
+// ReverseGeocodeProperties returns the raw GeoJSON properties of the first shape
+// containing the given coordinate, for properties that aren't in Location such
+// as WIKIDATAID or POP_EST. The first shape is the one whose fields take
+// priority in ReverseGeocode. It needs the WithProperties option, otherwise it
+// returns ErrNoProperties.
+//
+// The returned map is a copy, but any nested values are shared with the Rgeo
+// and must not be modified.
+func (r *Rgeo) ReverseGeocodeProperties(loc orb.Point) (map[string]interface{}, error) {
+	if !r.opts.properties {
+		return nil, ErrNoProperties
+	}
+
+	res := r.containingShapes(pointFromCoord(loc))
+	if len(res) == 0 {
+		return nil, ErrLocationNotFound
+	}
+
+	first := r.orderShapes(res)[0]
+
+	props := make(map[string]interface{}, len(r.props[first]))
+	for k, v := range r.props[first] {
+		props[k] = v
+	}
+
+	return props, nil
+}
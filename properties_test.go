@@ -0,0 +1,83 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+func TestReverseGeocodeProperties(t *testing.T) {
+	data := func() []byte { return compressData(t, twoSquaresGeo) }
+
+	r, err := NewWithOptions([]func() []byte{data}, WithProperties())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	props, err := r.ReverseGeocodeProperties(orb.Point{2.5, 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{"ADMIN": "East", "ISO_A3": "EST"}
+	if diff := deep.Equal(expected, props); diff != nil {
+		t.Error(diff)
+	}
+
+	// Changing the returned map mustn't change the stored one.
+	props["ADMIN"] = "Changed"
+	if props, _ := r.ReverseGeocodeProperties(orb.Point{2.5, 0.5}); props["ADMIN"] != "East" {
+		t.Errorf("expected stored properties to be unchanged, got: %v", props)
+	}
+
+	if _, err := r.ReverseGeocodeProperties(orb.Point{1.5, 0.5}); !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("expected error: %s\n got: %s\n", ErrLocationNotFound, err)
+	}
+
+	plain, err := New(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := plain.ReverseGeocodeProperties(orb.Point{2.5, 0.5}); !errors.Is(err, ErrNoProperties) {
+		t.Errorf("expected error: %s\n got: %s\n", ErrNoProperties, err)
+	}
+}
+
+func TestReverseGeocodeProperties_Priority(t *testing.T) {
+	squares := func() []byte { return compressData(t, twoSquaresGeo) }
+	enclave := func() []byte { return compressData(t, enclaveGeo) }
+
+	// Outer is loaded after East, so it only comes first on its priority.
+	r, err := NewWithOptions([]func() []byte{squares, enclave},
+		WithProperties(), WithDatasetPriority(enclave, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	props, err := r.ReverseGeocodeProperties(orb.Point{2.5, 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]interface{}{"ADMIN": "Outer", "CONTINENT": "Europe"}
+	if diff := deep.Equal(expected, props); diff != nil {
+		t.Error(diff)
+	}
+}
@@ -0,0 +1,71 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+// LocationsWithinRadius returns the Locations of all of the shapes which
+// intersect the circle of the given radius in meters around center, e.g. to
+// find every country within 100km of a point. A shape counts if any part of it,
+// including just its border, is within the radius, so a country touching the
+// edge of the circle is included. Duplicate Locations are only returned once,
+// in the order the shapes were loaded, and if nothing is within the radius it
+// returns ErrLocationNotFound.
+//
+// Distances are measured on a sphere like in NearestLocation, and a radius of 0
+// gives the Locations of the shapes containing center.
+func (r *Rgeo) LocationsWithinRadius(center orb.Point, meters float64) ([]Location, error) {
+	if meters < 0 || math.IsNaN(meters) {
+		return nil, fmt.Errorf("invalid radius: %v", meters)
+	}
+
+	p := pointFromCoord(center)
+
+	found := make(map[s2.Shape]bool)
+	for _, shape := range r.containingShapes(p) {
+		found[shape] = true
+	}
+
+	if meters > 0 {
+		for _, id := range r.edgeGrid().within(p, chordAngleFromMeters(meters)) {
+			found[r.index.Shape(id)] = true
+		}
+	}
+
+	if len(found) == 0 {
+		return nil, ErrLocationNotFound
+	}
+
+	return r.uniqueLocations(r.inLoadOrder(found)), nil
+}
+
+// inLoadOrder returns the shapes in the set, in the order they were loaded.
+func (r *Rgeo) inLoadOrder(set map[s2.Shape]bool) []s2.Shape {
+	shapes := make([]s2.Shape, 0, len(set))
+	for i := int32(0); i < int32(r.index.Len()); i++ {
+		if shape := r.index.Shape(i); set[shape] {
+			shapes = append(shapes, shape)
+		}
+	}
+
+	return shapes
+}
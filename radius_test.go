@@ -0,0 +1,90 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+func TestLocationsWithinRadius(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, twoSquaresGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	west := Location{Country: "West", CountryCode3: "WST"}
+	east := Location{Country: "East", CountryCode3: "EST"}
+
+	// (1.5, 0.5) is half a degree, around 55.6km, from both squares.
+	tests := []struct {
+		name     string
+		center   orb.Point
+		meters   float64
+		expected []Location
+		err      error
+	}{
+		{
+			name:     "Inside, no radius",
+			center:   orb.Point{0.5, 0.5},
+			expected: []Location{west},
+		},
+		{
+			name:     "Inside, reaching the other square",
+			center:   orb.Point{0.5, 0.5},
+			meters:   200000,
+			expected: []Location{west, east},
+		},
+		{
+			name:   "Between, too small",
+			center: orb.Point{1.5, 0.5},
+			meters: 50000,
+			err:    ErrLocationNotFound,
+		},
+		{
+			name:     "Between, touching both",
+			center:   orb.Point{1.5, 0.5},
+			meters:   60000,
+			expected: []Location{west, east},
+		},
+		{
+			name:     "Nearer east",
+			center:   orb.Point{1.9, 0.5},
+			meters:   20000,
+			expected: []Location{east},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := r.LocationsWithinRadius(test.center, test.meters)
+			if !errors.Is(err, test.err) {
+				t.Errorf("expected error: %v\n got: %v\n", test.err, err)
+			}
+			if diff := deep.Equal(test.expected, got); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+
+	if _, err := r.LocationsWithinRadius(orb.Point{0.5, 0.5}, -1); err == nil {
+		t.Error("expected error for negative radius")
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+
+	"github.com/golang/geo/s2"
+)
+
+// regionMaxLevel is the finest s2 cell level LocationsInRegion splits cells
+// down to, cells at level 24 are around 0.5-1m across.
+const regionMaxLevel = 24
+
+// LocationsInRegion returns the Locations of all of the shapes which intersect
+// the given s2 Region, such as an s2.Cap, s2.Rect, *s2.CellUnion or *s2.Polygon,
+// for spatial joins which the other methods don't cover. A shape counts if any
+// part of it is in the region, including if only their boundaries touch.
+// Duplicate Locations are only returned once, in the order the shapes were
+// loaded, and if nothing intersects the region it returns ErrLocationNotFound.
+//
+// The Region interface only allows testing whole s2 cells, so the
+// intersection is found by splitting the cells which both the region and a
+// shape partly cover into smaller ones, until one is found which one of them
+// contains and the other intersects. Cells are only split down to level 24,
+// around a metre across, so a shape up to around a metre from the region can
+// count as intersecting it. Shapes whose boundary runs alongside the region's
+// for a long way, within that distance, are the slowest to check.
+func (r *Rgeo) LocationsInRegion(region s2.Region) ([]Location, error) {
+	if region == nil {
+		return nil, errors.New("missing parameter: region")
+	}
+
+	bound := region.RectBound()
+	cells := region.CellUnionBound()
+
+	found := make(map[s2.Shape]bool)
+	for i := int32(0); i < int32(r.index.Len()); i++ {
+		p, ok := r.index.Shape(i).(*s2.Polygon)
+		if !ok || !bound.Intersects(p.RectBound()) {
+			continue
+		}
+
+		if regionIntersects(region, p, cells) {
+			found[p] = true
+		}
+	}
+
+	if len(found) == 0 {
+		return nil, ErrLocationNotFound
+	}
+
+	return r.uniqueLocations(r.inLoadOrder(found)), nil
+}
+
+// regionIntersects reports whether region and p intersect within the given
+// cells, splitting the cells they both partly cover down to regionMaxLevel.
+func regionIntersects(region s2.Region, p *s2.Polygon, cells []s2.CellID) bool {
+	for _, id := range cells {
+		cell := s2.CellFromCellID(id)
+		if !region.IntersectsCell(cell) || !p.IntersectsCell(cell) {
+			continue
+		}
+
+		if region.ContainsCell(cell) || p.ContainsCell(cell) || id.Level() >= regionMaxLevel {
+			return true
+		}
+
+		children := id.Children()
+		if regionIntersects(region, p, children[:]) {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/golang/geo/r1"
+	"github.com/golang/geo/s1"
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+func TestLocationsInRegion(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, twoSquaresGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	west := Location{Country: "West", CountryCode3: "WST"}
+	east := Location{Country: "East", CountryCode3: "EST"}
+
+	rect := func(minLng, maxLng float64) s2.Rect {
+		return s2.Rect{
+			Lat: r1.Interval{Lo: 0, Hi: s1.Degree.Radians()},
+			Lng: s1.Interval{Lo: minLng * s1.Degree.Radians(), Hi: maxLng * s1.Degree.Radians()},
+		}
+	}
+	circle := func(lng, lat, deg float64) s2.Cap {
+		return s2.CapFromCenterAngle(pointFromCoord(orb.Point{lng, lat}), s1.Angle(deg)*s1.Degree)
+	}
+
+	tests := []struct {
+		name     string
+		in       s2.Region
+		expected []Location
+		err      error
+	}{
+		{"RectBoth", rect(0.5, 2.5), []Location{west, east}, nil},
+		{"RectBetween", rect(1.2, 1.8), nil, ErrLocationNotFound},
+		{"RectTouching", rect(1, 1.5), []Location{west}, nil},
+		{"CapBoth", circle(1.5, 0.5, 0.6), []Location{west, east}, nil},
+		{"CapBetween", circle(1.5, 0.5, 0.4), nil, ErrLocationNotFound},
+		{"CapInside", circle(0.5, 0.5, 0.1), []Location{west}, nil},
+		{"CellUnion", &s2.CellUnion{s2.CellIDFromLatLng(s2.LatLngFromDegrees(0.5, 2.5)).Parent(10)}, []Location{east}, nil},
+		{"Polygon", s2.PolygonFromLoops([]*s2.Loop{s2.LoopFromPoints([]s2.Point{
+			pointFromCoord(orb.Point{0.5, -1}),
+			pointFromCoord(orb.Point{1.5, -1}),
+			pointFromCoord(orb.Point{1.5, 0.5}),
+			pointFromCoord(orb.Point{0.5, 0.5}),
+		})}), []Location{west}, nil},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			locs, err := r.LocationsInRegion(test.in)
+			if !errors.Is(err, test.err) {
+				t.Errorf("expected error: %v\n got: %v\n", test.err, err)
+			}
+			if diff := deep.Equal(test.expected, locs); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+
+	if _, err := r.LocationsInRegion(nil); err == nil {
+		t.Error("expected error for nil region")
+	}
+}
@@ -43,9 +43,11 @@ import (
 	"errors"
 	"fmt"
 	"github.com/paulmach/orb"
+	"io"
 	"reflect"
 	"runtime"
 	"sort"
+	"strconv"
 	"strings"
 	"sync"
 
@@ -65,14 +67,31 @@ type Location struct {
 	// Formal name of country
 	CountryLong string `json:"country_long,omitempty"`
 
+	// Name of the sovereign state, this differs from Country for dependent
+	// territories, e.g. Puerto Rico belongs to the United States of America
+	Sovereign string `json:"sovereign,omitempty"`
+
 	// ISO 3166-1 alpha-1 and alpha-2 codes
 	CountryCode2 string `json:"country_code_2,omitempty"`
 	CountryCode3 string `json:"country_code_3,omitempty"`
 
+	// ISO 3166-1 numeric code, e.g. "840" for the United States of America
+	CountryCodeNumeric string `json:"country_code_numeric,omitempty"`
+
+	// E.164 calling code, e.g. "+44", and ISO 4217 currency code, e.g. "GBP",
+	// only with WithCountryMetadata
+	CallingCode  string `json:"calling_code,omitempty"`
+	CurrencyCode string `json:"currency_code,omitempty"`
+
 	Continent string `json:"continent,omitempty"`
 	Region    string `json:"region,omitempty"`
 	SubRegion string `json:"subregion,omitempty"`
 
+	// UN M49 codes of the Region and SubRegion, e.g. "150" and "154" for
+	// Northern Europe
+	RegionCode    string `json:"region_code,omitempty"`
+	SubRegionCode string `json:"subregion_code,omitempty"`
+
 	Province string `json:"province,omitempty"`
 
 	// ISO 3166-2 code
@@ -80,7 +99,16 @@ type Location struct {
 
 	County string `json:"county,omitempty"`
 
+	// Natural Earth admin-2 code, e.g. "USA-53065", for US counties the part
+	// after the dash is the FIPS code
+	CountyCode string `json:"county_code,omitempty"`
+
 	City string `json:"city,omitempty"`
+
+	// Identifier of the matched feature from the dataset, for joining results
+	// back to the source data, e.g. Natural Earth's NE_ID. See
+	// WithFeatureIDProperty.
+	FeatureID string `json:"feature_id,omitempty"`
 }
 
 type LocationWithGeometry struct {
@@ -90,9 +118,12 @@ type LocationWithGeometry struct {
 
 type GeomLookup map[string]map[s2.Shape]orb.Geometry
 
-// getFunctionName returns rgeo.Countries110, rgeo.Countries10, etc.
+// getFunctionName returns rgeo.Countries110, rgeo.Countries10, etc. Method
+// values have the "-fm" suffix the compiler adds to their wrappers removed, so
+// they get the same name as the method.
 func getFunctionName(i interface{}) string {
-	return runtime.FuncForPC(reflect.ValueOf(i).Pointer()).Name()
+	name := runtime.FuncForPC(reflect.ValueOf(i).Pointer()).Name()
+	return strings.TrimSuffix(name, "-fm")
 }
 
 // Rgeo is the type used to hold pre-created polygons for reverse geocoding.
@@ -101,15 +132,79 @@ type Rgeo struct {
 	locs  map[s2.Shape]Location
 	geoms GeomLookup
 	query *s2.ContainsPointQuery
+	opts  options
+
+	// vmQueries are the queries for ReverseGeocodeVM with vertex models other
+	// than the open one used by query, they're only made when first used and
+	// are guarded by containsPointQueryLock.
+	vmQueries map[s2.VertexModel]*s2.ContainsPointQuery
+
+	// datasets holds the names of the loaded datasets in the order they were
+	// first loaded, for DatasetNames.
+	datasets []string
+
+	// order holds the shapes of each dataset in the order of the features
+	// they were loaded from, as geoms can't keep it.
+	order map[string][]s2.Shape
+
+	// levels holds the finest AdminLevel of the shapes in each dataset, for
+	// Capabilities.
+	levels map[string]AdminLevel
+
+	// cityPops holds the population of each city shape, only when it's needed
+	// for CityLargestPopulation.
+	cityPops map[s2.Shape]float64
+
+	// areas holds the area of each shape, only when it's needed for
+	// ShapeSmallest or ShapeLargest.
+	areas map[s2.Shape]float64
+
+	// priorities holds the dataset priority of each shape, only for datasets
+	// given one with WithDatasetPriority.
+	priorities map[s2.Shape]int
+
+	// validity holds the validity period of each shape, only for datasets
+	// given one with WithDatasetValidity.
+	validity map[s2.Shape]validity
+
+	// altitudes holds the altitude range of each shape, only for shapes with
+	// the properties from WithAltitudeProperties.
+	altitudes map[s2.Shape]altitudeRange
+
+	// props holds the GeoJSON properties of each shape, only with the
+	// WithProperties option.
+	props map[s2.Shape]map[string]interface{}
+
+	// edges is used to find the nearest shape to a point, it's only built the
+	// first time it's needed.
+	edges     *edgeGrid
+	edgesOnce sync.Once
+
+	// cities holds the centre of each city shape for NearestCity, it's only
+	// worked out the first time it's needed.
+	cities     []cityCentre
+	citiesOnce sync.Once
+
+	// neighbours caches the neighbouring country shapes of each country shape
+	// for Neighbors, it's guarded by neighboursMu.
+	neighbours   map[s2.Shape][]s2.Shape
+	neighboursMu sync.Mutex
+
+	// provinces caches the geometry of each province shape dissolved from its
+	// counties for ProvinceGeometry, it's guarded by provincesMu.
+	provinces   map[s2.Shape]orb.Geometry
+	provincesMu sync.Mutex
 }
 
 // Go generate commands to regenerate the included datasets, this assumes you
 // have the GeoJSON files from
-// https://github.com/nvkelso/natural-earth-vector/tree/master/geojson.
+// https://github.com/nvkelso/natural-earth-vector/tree/master/geojson. To
+// download them instead, use the -url flag with the raw file URLs from there.
 // go run datagen/datagen.go -ne -o Countries110 ne_110m_admin_0_countries.geojson
 // go run datagen/datagen.go -ne -o Countries10 ne_10m_admin_0_countries.geojson
 // go run datagen/datagen.go -ne -o Provinces10 -merge ne_10m_admin_0_countries.geojson ne_10m_admin_1_states_provinces.geojson
 // go run datagen/datagen.go -ne -o Cities10 ne_10m_urban_areas_landscan.geojson
+// go run datagen/datagen.go -ne -o US_Counties10 ne_10m_admin_2_counties.geojson
 
 // New returns an Rgeo struct which can then be used with ReverseGeocode. It
 // takes any number of datasets as an argument. The included datasets are:
@@ -118,81 +213,322 @@ type Rgeo struct {
 // well. Cities10 only includes cities so you'll probably want to use
 // Provinces10 with it.
 func New(datasets ...func() []byte) (*Rgeo, error) {
+	return NewWithOptions(datasets)
+}
 
+// NewWithOptions works like New, but also takes Options to change how the
+// datasets are loaded and queried.
+func NewWithOptions(datasets []func() []byte, opts ...Option) (*Rgeo, error) {
 	// Initialise Rgeo struct
-	ret := new(Rgeo)
-	ret.index = s2.NewShapeIndex()
-	ret.locs = make(map[s2.Shape]Location)
-	ret.geoms = GeomLookup{}
-
-	for i, dataset := range datasets {
-		br := bytes.NewReader(dataset())
-		if br.Len() == 0 {
-			return nil, fmt.Errorf("no data in dataset %d", i)
+	ret := newRgeo(opts...)
+
+	if err := ret.loadDatasets(datasets); err != nil {
+		return nil, err
+	}
+
+	/*
+		ContainsPointQuery determines whether one or more shapes in a ShapeIndex contain a given Point. The ShapeIndex may contain any number of points, polylines, and/ or polygons (possibly overlapping). Shape boundaries may be modeled as Open, SemiOpen, or Closed (this affects whether or not shapes are considered to contain their vertices).
+		***************************************************
+		**** This type is not safe for concurrent use. ****
+		***************************************************
+	*/
+	ret.query = s2.NewContainsPointQuery(ret.index, s2.VertexModelOpen)
+
+	return ret, nil
+}
+
+// decodeDataset decodes a GeoJSON FeatureCollection from b, decompressing it
+// first if compressed is true. The Decompressor is picked from the header of
+// b, falling back to gzip for its error. desc describes the dataset in errors.
+func decodeDataset(b []byte, compressed bool, desc string) (*geojson.FeatureCollection, error) {
+	if len(b) == 0 {
+		return nil, fmt.Errorf("no data in %s", desc)
+	}
+
+	var (
+		r  io.Reader = bytes.NewReader(b)
+		zr io.ReadCloser
+	)
+
+	if compressed {
+		decompress, ok := decompressorFor(b)
+		if !ok {
+			decompress = func(r io.Reader) (io.ReadCloser, error) { return gzip.NewReader(r) }
 		}
 
-		zr, err := gzip.NewReader(br)
+		var err error
+		zr, err = decompress(r)
 		if err != nil {
-			return nil, fmt.Errorf("decompression failed for dataset %d: %w", i, err)
+			return nil, fmt.Errorf("decompression failed for %s: %w", desc, err)
 		}
 
-		// Parse GeoJSON
-		var tfc geojson.FeatureCollection
-		if err := json.NewDecoder(zr).Decode(&tfc); err != nil {
-			return nil, fmt.Errorf("invalid JSON in dataset %d: %w", i, err)
-		}
+		r = zr
+	}
+
+	// Parse GeoJSON
+	var fc geojson.FeatureCollection
+	if err := json.NewDecoder(r).Decode(&fc); err != nil {
+		return nil, fmt.Errorf("invalid JSON in %s: %w", desc, err)
+	}
 
+	if zr != nil {
 		if err := zr.Close(); err != nil {
-			return nil, fmt.Errorf("failed to close gzip reader for dataset %d: %w", i, err)
+			return nil, fmt.Errorf("failed to close decompressor for %s: %w", desc, err)
 		}
+	}
 
-		datasetName := getFunctionName(dataset)
-		shpGeoms, ok := ret.geoms[datasetName]
-		if !ok {
-			shpGeoms = make(map[s2.Shape]orb.Geometry, len(tfc.Features))
-			ret.geoms[datasetName] = shpGeoms
-		}
-		for _, c := range tfc.Features {
-			// Convert GeoJSON features from geom (multi)polygons to s2 polygons
-			p, err := polygonFromGeometry(c.Geometry)
-			if err != nil {
-				return nil, fmt.Errorf("bad polygon in geometry: %w", err)
-			}
-			ret.geoms[datasetName][p] = c.Geometry
+	return &fc, nil
+}
 
-			ret.index.Add(p)
+// NewFromFeatureCollection returns an Rgeo struct built from a single GeoJSON
+// FeatureCollection, skipping the decompression and decoding done by New. The
+// features go through the same conversion as in New, so they must all be
+// Polygons or MultiPolygons. The name is used as the dataset name, for
+// ReverseGeocodeWithGeometry and DatasetNames, so it can't be empty.
+//
+// This is mostly useful for building small known datasets in tests.
+func NewFromFeatureCollection(fc *geojson.FeatureCollection, name string, opts ...Option) (*Rgeo, error) {
+	if fc == nil {
+		return nil, errors.New("nil feature collection")
+	}
+	if name == "" {
+		return nil, errors.New("missing parameter: dataset name")
+	}
 
-			// The s2 ContainsPointQuery returns the shapes that contain the given
-			// point, but I haven't found any way to attach the location information
-			// to the shapes, so I use a map to get the information.
-			loc := getLocationStrings(c.Properties)
-			ret.locs[p] = loc
-		}
+	ret := newRgeo(opts...)
+	if err := ret.addFeatureCollection(fc, name); err != nil {
+		return nil, err
 	}
 
-	/*
-		ContainsPointQuery determines whether one or more shapes in a ShapeIndex contain a given Point. The ShapeIndex may contain any number of points, polylines, and/ or polygons (possibly overlapping). Shape boundaries may be modeled as Open, SemiOpen, or Closed (this affects whether or not shapes are considered to contain their vertices).
-		***************************************************
-		**** This type is not safe for concurrent use. ****
-		***************************************************
-	*/
 	ret.query = s2.NewContainsPointQuery(ret.index, s2.VertexModelOpen)
 
 	return ret, nil
 }
 
+// newRgeo returns an empty Rgeo with its maps and index initialised and the
+// given options applied.
+func newRgeo(opts ...Option) *Rgeo {
+	ret := &Rgeo{
+		index: s2.NewShapeIndex(),
+		locs:  make(map[s2.Shape]Location),
+		geoms: GeomLookup{},
+		order: make(map[string][]s2.Shape),
+
+		levels: make(map[string]AdminLevel),
+
+		cityPops:   make(map[s2.Shape]float64),
+		areas:      make(map[s2.Shape]float64),
+		priorities: make(map[s2.Shape]int),
+		validity:   make(map[s2.Shape]validity),
+		altitudes:  make(map[s2.Shape]altitudeRange),
+		props:      make(map[s2.Shape]map[string]interface{}),
+	}
+
+	for _, opt := range opts {
+		opt(&ret.opts)
+	}
+
+	return ret
+}
+
+// addFeatureCollection converts the features in fc to s2 polygons and adds them
+// to the index under the given dataset name.
+func (r *Rgeo) addFeatureCollection(fc *geojson.FeatureCollection, datasetName string) error {
+	shpGeoms := r.datasetGeoms(datasetName, len(fc.Features))
+
+	strs := make(interner)
+
+	for i, c := range fc.Features {
+		if err := r.addFeature(c.Geometry, c.Properties, datasetName, shpGeoms, strs); err != nil {
+			return err
+		}
+
+		r.reportProgress(datasetName, i+1, len(fc.Features))
+	}
+
+	if len(fc.Features) == 0 {
+		r.reportProgress(datasetName, 0, 0)
+	}
+
+	return nil
+}
+
+// datasetGeoms returns the map of shapes to geometries for the named dataset,
+// adding the dataset with room for n shapes if it isn't loaded yet.
+func (r *Rgeo) datasetGeoms(name string, n int) map[s2.Shape]orb.Geometry {
+	shpGeoms, ok := r.geoms[name]
+	if !ok {
+		shpGeoms = make(map[s2.Shape]orb.Geometry, n)
+		r.geoms[name] = shpGeoms
+		r.datasets = append(r.datasets, name)
+	}
+
+	return shpGeoms
+}
+
+// addFeature converts a single feature to an s2 polygon and adds it to the
+// index as part of the named dataset, with its geometry stored in shpGeoms.
+func (r *Rgeo) addFeature(g orb.Geometry, props map[string]interface{}, dataset string,
+	shpGeoms map[s2.Shape]orb.Geometry, strs interner,
+) error {
+	f, ok, err := r.prepareFeature(g, props)
+	if err != nil || !ok {
+		return err
+	}
+
+	r.insertFeature(f, dataset, shpGeoms, strs)
+
+	return nil
+}
+
+// feature is a GeoJSON feature converted by prepareFeature, ready to be added
+// to the index.
+type feature struct {
+	geom  orb.Geometry
+	props map[string]interface{}
+	shape *s2.Polygon
+	loc   Location
+
+	// alt is the altitude range of the shape, if hasAlt is true.
+	alt    altitudeRange
+	hasAlt bool
+}
+
+// prepareFeature converts a feature to an s2 polygon and works out its
+// Location. It doesn't change r, so it can be called concurrently. ok is false
+// if the feature should be skipped, because of the filter or because every
+// ring had no area.
+func (r *Rgeo) prepareFeature(g orb.Geometry, props map[string]interface{}) (f feature, ok bool, err error) {
+	if r.opts.filter != nil && !r.opts.filter(props) {
+		return feature{}, false, nil
+	}
+
+	if g == nil {
+		// Metadata only features have a null geometry.
+		if r.opts.rejectMissingGeometry {
+			return feature{}, false, ErrMissingGeometry
+		}
+
+		return feature{}, false, nil
+	}
+
+	// Convert GeoJSON features from geom (multi)polygons to s2 polygons
+	p, err := polygonFromGeometry(g, r.opts)
+	if err != nil {
+		return feature{}, false, fmt.Errorf("bad polygon in geometry: %w", err)
+	}
+	if p.NumLoops() == 0 {
+		// Every ring had no area.
+		return feature{}, false, nil
+	}
+
+	// The s2 ContainsPointQuery returns the shapes that contain the given
+	// point, but I haven't found any way to attach the location information
+	// to the shapes, so I use a map to get the information.
+	loc := getLocationStrings(props)
+	loc = localize(loc, props, r.opts.language)
+	loc.FeatureID = getFeatureID(props, r.opts.featureIDKeys)
+	if r.opts.countryMetadata != nil {
+		loc = r.opts.countryMetadata(loc)
+	}
+	if r.opts.fields != 0 {
+		loc = loc.only(r.opts.fields)
+	}
+
+	f = feature{geom: g, props: props, shape: p, loc: loc}
+	if r.opts.altitudeKeys != nil {
+		f.alt, f.hasAlt, err = getAltitudeRange(props, r.opts.altitudeKeys)
+		if err != nil {
+			return feature{}, false, err
+		}
+	}
+
+	return f, true, nil
+}
+
+// insertFeature adds a feature from prepareFeature to the index as part of the
+// named dataset, with its geometry stored in shpGeoms.
+func (r *Rgeo) insertFeature(f feature, dataset string, shpGeoms map[s2.Shape]orb.Geometry, strs interner) {
+	p := f.shape
+
+	// Datasets without geometry still get an entry, as it's also used to
+	// tell which dataset the shape is from.
+	if r.keepsGeometry(dataset) {
+		shpGeoms[p] = f.geom
+	} else {
+		shpGeoms[p] = nil
+	}
+
+	r.index.Add(p)
+	r.order[dataset] = append(r.order[dataset], p)
+
+	r.locs[p] = strs.location(f.loc)
+	r.addLevel(dataset, f.loc)
+
+	if r.opts.properties {
+		r.props[p] = f.props
+	}
+
+	if r.opts.shapePreference != ShapeFirst {
+		r.areas[p] = p.Area()
+	}
+
+	r.setDatasetOptions(p, dataset)
+
+	if f.hasAlt {
+		r.altitudes[p] = f.alt
+	}
+
+	if f.loc.City != "" && r.opts.cityPreference == CityLargestPopulation {
+		r.cityPops[p] = getPropertyFloat(f.props, populationKeys...)
+	}
+}
+
 // containsPointQueryLock is used to prevent concurrent access to the ContainsPointQuery.
 // The type is not safe for concurrent use.
 var containsPointQueryLock = sync.Mutex{}
 
+// containingShapes returns the shapes which contain p.
+func (r *Rgeo) containingShapes(p s2.Point) []s2.Shape {
+	containsPointQueryLock.Lock()
+	defer containsPointQueryLock.Unlock()
+
+	return r.query.ContainingShapes(p)
+}
+
+// DatasetNames returns the sorted names of the loaded datasets, which are the
+// names to use with ReverseGeocodeWithGeometry and GetGeometry. Datasets
+// loaded with New or NewWithOptions are named after their function, including
+// the package path (e.g. "github.com/sams96/rgeo.Countries10"), and ones
+// loaded with NewFromFeatureCollection use the name they were given. A dataset
+// is listed even if none of its features were kept, e.g. because of
+// WithFeatureFilter, and a dataset loaded more than once is only listed once.
 func (r *Rgeo) DatasetNames() []string {
-	names := make([]string, 0, len(r.geoms))
-	for k := range r.geoms {
-		names = append(names, k)
+	names := make([]string, len(r.datasets))
+	copy(names, r.datasets)
+	sort.Strings(names)
+
+	return names
+}
+
+// DatasetsContaining returns the sorted names of the datasets with a shape
+// containing the given coordinate. This is useful for finding where datasets
+// disagree, e.g. a point near the coast which is in Provinces10 but not in
+// Countries10.
+func (r *Rgeo) DatasetsContaining(loc orb.Point) []string {
+	res := r.containingShapes(pointFromCoord(loc))
+
+	var names []string
+	for name, shpGeoms := range r.geoms {
+		for _, shape := range res {
+			if _, ok := shpGeoms[shape]; ok {
+				names = append(names, name)
+				break
+			}
+		}
 	}
-	sort.Slice(names, func(i, j int) bool {
-		return strings.Compare(names[i], names[j]) < 0
-	})
+	sort.Strings(names)
+
 	return names
 }
 
@@ -201,46 +537,151 @@ func (r *Rgeo) DatasetNames() []string {
 // The input is an orb.Point, which is just a []float64 with the longitude
 // in the zeroth position and the latitude in the first position
 // (i.e. []float64{lon, lat}).
+//
+// If the coordinate isn't in any shape it returns ErrLocationNotFound, or the
+// Location from WithFallbackLocation.
 func (r *Rgeo) ReverseGeocode(loc orb.Point) (Location, error) {
-	containsPointQueryLock.Lock()
-	res := r.query.ContainingShapes(pointFromCoord(loc))
-	containsPointQueryLock.Unlock()
+	return r.withFallback(r.reverseGeocode(pointFromCoord(loc)))
+}
+
+// withFallback returns the Location from WithFallbackLocation in place of
+// ErrLocationNotFound, if it's set, otherwise l and err as they are.
+func (r *Rgeo) withFallback(l Location, err error) (Location, error) {
+	if r.opts.fallback != nil && errors.Is(err, ErrLocationNotFound) {
+		return *r.opts.fallback, nil
+	}
+
+	return l, err
+}
+
+// reverseGeocode is the core of ReverseGeocode, split out from the conversion
+// of the coordinate so that the two can be benchmarked and profiled apart.
+func (r *Rgeo) reverseGeocode(p s2.Point) (Location, error) {
+	res := r.containingShapes(p)
 	if len(res) == 0 {
 		return Location{}, ErrLocationNotFound
 	}
 
-	return r.combineLocations(res), nil
+	l := r.combineLocations(res)
+	if r.opts.snapMeters > 0 {
+		l = r.snapCoarser(p, res, l)
+	}
+
+	return l, nil
 }
 
-// combineLocations combines the Locations for the given s2 Shapes.
+// ReverseGeocodeExcluding works like ReverseGeocode, but ignores the shapes in
+// the given datasets, e.g. to get only administrative results when Cities10 is
+// loaded. The names are the ones returned by DatasetNames, and any that aren't
+// loaded give an error.
+func (r *Rgeo) ReverseGeocodeExcluding(loc orb.Point, excludeDatasets ...string) (Location, error) {
+	for _, name := range excludeDatasets {
+		if _, ok := r.geoms[name]; !ok {
+			return Location{}, fmt.Errorf("dataset not found: %q (have %v)", name, r.DatasetNames())
+		}
+	}
+
+	res := r.containingShapes(pointFromCoord(loc))
+
+	kept := make([]s2.Shape, 0, len(res))
+	for _, shape := range res {
+		if !r.inDatasets(shape, excludeDatasets) {
+			kept = append(kept, shape)
+		}
+	}
+
+	if len(kept) == 0 {
+		return Location{}, ErrLocationNotFound
+	}
+
+	return r.combineLocations(kept), nil
+}
+
+// inDatasets reports whether shape was loaded from any of the given datasets.
+func (r *Rgeo) inDatasets(shape s2.Shape, datasets []string) bool {
+	for _, name := range datasets {
+		if _, ok := r.geoms[name][shape]; ok {
+			return true
+		}
+	}
+
+	return false
+}
+
+// combineLocations combines the Locations for the given s2 Shapes, in the
+// order given by the dataset priorities and the ShapePreference, up to the
+// limit from WithMaxShapes. The FeatureID is the one from the most specific
+// shape, e.g. the province rather than the country.
 func (r *Rgeo) combineLocations(s []s2.Shape) (l Location) {
+	// A single shape, the common case for one dataset, is just its Location.
+	if len(s) == 1 {
+		return r.locs[s[0]]
+	}
+
+	s = r.orderShapes(s)
+	if max := r.opts.maxShapes; max > 0 && len(s) > max {
+		s = s[:max]
+	}
+
+	var idLevel AdminLevel
 	for _, shape := range s {
 		loc := r.locs[shape]
-		l = Location{
-			Country:      firstNonEmpty(l.Country, loc.Country),
-			CountryLong:  firstNonEmpty(l.CountryLong, loc.CountryLong),
-			CountryCode2: firstNonEmpty(l.CountryCode2, loc.CountryCode2),
-			CountryCode3: firstNonEmpty(l.CountryCode3, loc.CountryCode3),
-			Continent:    firstNonEmpty(l.Continent, loc.Continent),
-			Region:       firstNonEmpty(l.Region, loc.Region),
-			SubRegion:    firstNonEmpty(l.SubRegion, loc.SubRegion),
-			Province:     firstNonEmpty(l.Province, loc.Province),
-			ProvinceCode: firstNonEmpty(l.ProvinceCode, loc.ProvinceCode),
-			County:       firstNonEmpty(l.County, loc.County),
-			City:         firstNonEmpty(l.City, loc.City),
+		l.fillFrom(&loc)
+
+		if level := loc.adminLevel(); loc.FeatureID != "" && (l.FeatureID == "" || level > idLevel) {
+			l.FeatureID, idLevel = loc.FeatureID, level
 		}
 	}
 
+	if len(s) > 1 && r.opts.cityPreference != CityFirst {
+		l.City = firstNonEmpty(r.preferredCity(s), l.City)
+	}
+
 	return
 }
 
+// fill returns l with each of its empty fields set to the one from loc, apart
+// from the FeatureID which is left as it is.
+func (l Location) fill(loc Location) Location {
+	l.fillFrom(&loc)
+	return l
+}
+
+// fillFrom sets each of the empty fields of l to the one from loc, apart from
+// the FeatureID, in place rather than building a new Location.
+func (l *Location) fillFrom(loc *Location) {
+	setIfEmpty(&l.Country, loc.Country)
+	setIfEmpty(&l.CountryLong, loc.CountryLong)
+	setIfEmpty(&l.Sovereign, loc.Sovereign)
+	setIfEmpty(&l.CountryCode2, loc.CountryCode2)
+	setIfEmpty(&l.CountryCode3, loc.CountryCode3)
+	setIfEmpty(&l.CountryCodeNumeric, loc.CountryCodeNumeric)
+	setIfEmpty(&l.CallingCode, loc.CallingCode)
+	setIfEmpty(&l.CurrencyCode, loc.CurrencyCode)
+	setIfEmpty(&l.Continent, loc.Continent)
+	setIfEmpty(&l.Region, loc.Region)
+	setIfEmpty(&l.SubRegion, loc.SubRegion)
+	setIfEmpty(&l.RegionCode, loc.RegionCode)
+	setIfEmpty(&l.SubRegionCode, loc.SubRegionCode)
+	setIfEmpty(&l.Province, loc.Province)
+	setIfEmpty(&l.ProvinceCode, loc.ProvinceCode)
+	setIfEmpty(&l.County, loc.County)
+	setIfEmpty(&l.CountyCode, loc.CountyCode)
+	setIfEmpty(&l.City, loc.City)
+}
+
+// setIfEmpty sets *dst to src if it is empty.
+func setIfEmpty(dst *string, src string) {
+	if *dst == "" {
+		*dst = src
+	}
+}
+
 func (r *Rgeo) ReverseGeocodeWithGeometry(loc orb.Point, dataset string) (LocationWithGeometry, error) {
 	if dataset == "" {
 		return LocationWithGeometry{}, fmt.Errorf("missing parameter: geometry dataset")
 	}
-	containsPointQueryLock.Lock()
-	res := r.query.ContainingShapes(pointFromCoord(loc))
-	containsPointQueryLock.Unlock()
+	res := r.containingShapes(pointFromCoord(loc))
 	if len(res) == 0 {
 		return LocationWithGeometry{}, ErrLocationNotFound
 	}
@@ -248,6 +689,9 @@ func (r *Rgeo) ReverseGeocodeWithGeometry(loc orb.Point, dataset string) (Locati
 	if !ok {
 		return LocationWithGeometry{}, fmt.Errorf("dataset geometries not found: %q", dataset)
 	}
+	if !r.keepsGeometry(dataset) {
+		return LocationWithGeometry{}, r.errNoGeometry(dataset)
+	}
 	out := LocationWithGeometry{Location: r.combineLocations(res)}
 	// Assign the geometry that has a match on shape in this dataset.
 	for _, shp := range res {
@@ -261,26 +705,35 @@ func (r *Rgeo) ReverseGeocodeWithGeometry(loc orb.Point, dataset string) (Locati
 }
 
 func (r *Rgeo) GetGeometry(loc orb.Point, dataset string) (orb.Geometry, error) {
+	_, geom, err := r.datasetShape(loc, dataset)
+	if err == nil && !r.keepsGeometry(dataset) {
+		return nil, r.errNoGeometry(dataset)
+	}
+
+	return geom, err
+}
+
+// datasetShape returns the first shape from the given dataset which contains
+// loc, along with its geometry.
+func (r *Rgeo) datasetShape(loc orb.Point, dataset string) (s2.Shape, orb.Geometry, error) {
 	if dataset == "" {
-		return nil, fmt.Errorf("missing parameter: geometry dataset")
+		return nil, nil, fmt.Errorf("missing parameter: geometry dataset")
 	}
-	containsPointQueryLock.Lock()
-	res := r.query.ContainingShapes(pointFromCoord(loc))
-	containsPointQueryLock.Unlock()
+	res := r.containingShapes(pointFromCoord(loc))
 	if len(res) == 0 {
-		return nil, ErrLocationNotFound
+		return nil, nil, ErrLocationNotFound
 	}
 	shpGeom, ok := r.geoms[dataset]
 	if !ok {
-		return nil, fmt.Errorf("dataset not found: %q (have %v)", dataset, r.DatasetNames())
+		return nil, nil, fmt.Errorf("dataset not found: %q (have %v)", dataset, r.DatasetNames())
 	}
 	for _, shp := range res {
 		geom, ok := shpGeom[shp]
 		if ok {
-			return geom, nil
+			return shp, geom, nil
 		}
 	}
-	return nil, fmt.Errorf("no geometry found for dataset %q", dataset)
+	return nil, nil, fmt.Errorf("no geometry found for dataset %q", dataset)
 }
 
 // firstNonEmpty returns the first non empty parameter.
@@ -297,23 +750,77 @@ func firstNonEmpty(s ...string) string {
 // Get the relevant strings from the GeoJSON properties.
 func getLocationStrings(p map[string]interface{}) Location {
 	loc := Location{
-		Country:      getPropertyString(p, "ADMIN", "admin"),
-		CountryLong:  getPropertyString(p, "FORMAL_EN"),
-		CountryCode2: getPropertyString(p, "ISO_A2"),
-		CountryCode3: getPropertyString(p, "ISO_A3"),
-		Continent:    getPropertyString(p, "CONTINENT"),
-		Region:       getPropertyString(p, "REGION_UN"),
-		SubRegion:    getPropertyString(p, "SUBREGION"),
-		Province:     getPropertyString(p, "name"),
-		ProvinceCode: getPropertyString(p, "iso_3166_2"),
-		City:         strings.TrimSuffix(getPropertyString(p, "name_conve"), "2"),
-	}
-	if t, ok := p["TYPE"]; ok && t == "County" {
+		Country:            getPropertyString(p, "ADMIN", "admin"),
+		CountryLong:        getPropertyString(p, "FORMAL_EN"),
+		Sovereign:          getPropertyString(p, "SOVEREIGNT"),
+		CountryCode2:       getPropertyString(p, "ISO_A2"),
+		CountryCode3:       getPropertyString(p, "ISO_A3"),
+		CountryCodeNumeric: getISONumeric(p),
+		Continent:          getPropertyString(p, "CONTINENT"),
+		Region:             getPropertyString(p, "REGION_UN"),
+		SubRegion:          getPropertyString(p, "SUBREGION"),
+		RegionCode:         Region(getPropertyString(p, "REGION_UN")).M49(),
+		SubRegionCode:      SubRegion(getPropertyString(p, "SUBREGION")).M49(),
+		Province:           getPropertyString(p, "name"),
+		ProvinceCode:       getPropertyString(p, "iso_3166_2"),
+		City:               strings.TrimSuffix(getPropertyString(p, "name_conve"), "2"),
+	}
+	if isAdmin2(p) {
 		loc.County = getPropertyString(p, "NAME")
+		loc.CountyCode = getPropertyString(p, "ADM2_CODE")
 	}
 	return loc
 }
 
+// defaultFeatureIDKeys are the GeoJSON properties read for the FeatureID when
+// WithFeatureIDProperty isn't used, Natural Earth's admin-0 datasets use NE_ID
+// and its admin-1 datasets use ne_id.
+var defaultFeatureIDKeys = []string{"NE_ID", "ne_id"}
+
+// getFeatureID returns the FeatureID from the first of the given GeoJSON
+// properties which is set, or from defaultFeatureIDKeys if keys is nil. Numbers
+// are formatted without an exponent, so 1159320625 doesn't become
+// "1.159320625e+09". Anything other than a string or a number is ignored.
+func getFeatureID(p map[string]interface{}, keys []string) string {
+	if keys == nil {
+		keys = defaultFeatureIDKeys
+	}
+
+	for _, k := range keys {
+		switch v := p[k].(type) {
+		case string:
+			if v != "" {
+				return v
+			}
+		case float64:
+			return strconv.FormatFloat(v, 'f', -1, 64)
+		}
+	}
+
+	return ""
+}
+
+// isAdmin2 reports whether the GeoJSON properties are for an admin-2 area such
+// as a county. As well as counties, the Natural Earth admin-2 data has
+// parishes, boroughs and others which are only marked by their FEATURECLA.
+func isAdmin2(p map[string]interface{}) bool {
+	return p["TYPE"] == "County" || p["FEATURECLA"] == "Admin-2 scale rank"
+}
+
+// getISONumeric returns the ISO 3166-1 numeric code from the GeoJSON
+// properties. Natural Earth uses "-99" when ISO_N3 isn't set, in which case
+// ISO_N3_EH is tried instead, as it has codes for some countries that ISO_N3
+// is missing (e.g. France and Norway).
+func getISONumeric(p map[string]interface{}) string {
+	for _, k := range []string{"ISO_N3", "ISO_N3_EH"} {
+		if s := getPropertyString(p, k); s != "" && s != "-99" {
+			return s
+		}
+	}
+
+	return ""
+}
+
 // getPropertyString gets the value from a map given the key as a string, or
 // from the next given key if the previous fails.
 func getPropertyString(m map[string]interface{}, keys ...string) (s string) {
@@ -328,35 +835,51 @@ func getPropertyString(m map[string]interface{}, keys ...string) (s string) {
 	return
 }
 
-// polygonFromGeometry converts a geom.T to an s2 Polygon.
-func polygonFromGeometry(g orb.Geometry) (*s2.Polygon, error) {
-	var (
-		polygon *s2.Polygon
-		err     error
-	)
-
-	switch t := g.(type) {
-	case orb.Polygon:
-		polygon, err = polygonFromPolygon(t)
-	case orb.MultiPolygon:
-		polygon, err = polygonFromMultiPolygon(t)
-	default:
-		return nil, errors.New("needs Polygon or MultiPolygon")
+// getPropertyFloat gets a numeric value from a map given the key as a string,
+// or from the next given key if the previous fails.
+func getPropertyFloat(m map[string]interface{}, keys ...string) float64 {
+	for _, k := range keys {
+		if f, ok := m[k].(float64); ok {
+			return f
+		}
 	}
 
+	return 0
+}
+
+// ErrDegenerateRing is wrapped in the error returned for rings with no area,
+// such as ones where all of the points are in a line, when the
+// RejectDegenerateRings option is used.
+var ErrDegenerateRing = errors.New("ring has no area")
+
+// ErrMissingGeometry is returned for features with a null geometry when the
+// RejectMissingGeometry option is used.
+var ErrMissingGeometry = errors.New("feature has no geometry")
+
+// ErrWrongWinding is wrapped in the error returned for rings which don't follow
+// the RFC 7946 winding order, when the RequireRFC7946Winding option is used.
+var ErrWrongWinding = errors.New("ring not wound as in RFC 7946")
+
+// polygonFromGeometry converts a geom.T to an s2 Polygon. Rings with no area
+// are skipped, or give an error wrapping ErrDegenerateRing with the
+// RejectDegenerateRings option. Rings of either winding are accepted, unless
+// the RequireRFC7946Winding option is used.
+func polygonFromGeometry(g orb.Geometry, opts options) (*s2.Polygon, error) {
+	src, err := orbSource(g)
 	if err != nil {
 		return nil, err
 	}
 
-	return polygon, nil
+	return polygonFromSource(src, opts)
 }
 
-// Converts a geom MultiPolygon to an s2 Polygon.
-func polygonFromMultiPolygon(p orb.MultiPolygon) (*s2.Polygon, error) {
-	loops := make([]*s2.Loop, 0, len(p))
+// polygonFromSource converts the polygons from a PolygonSource to a single s2
+// Polygon, in the same way as polygonFromGeometry.
+func polygonFromSource(src PolygonSource, opts options) (*s2.Polygon, error) {
+	loops := make([]*s2.Loop, 0, src.NumPolygons())
 
-	for i := 0; i < len(p); i++ {
-		this, err := loopSliceFromPolygon(p[i])
+	for i := 0; i < src.NumPolygons(); i++ {
+		this, err := loopSliceFromPolygon(sourcePolygon(src, i), opts)
 		if err != nil {
 			return nil, err
 		}
@@ -367,16 +890,15 @@ func polygonFromMultiPolygon(p orb.MultiPolygon) (*s2.Polygon, error) {
 	return s2.PolygonFromLoops(loops), nil
 }
 
-// Converts a geom Polygon to an s2 Polygon.
-func polygonFromPolygon(p orb.Polygon) (*s2.Polygon, error) {
-	loops, err := loopSliceFromPolygon(p)
-	return s2.PolygonFromLoops(loops), err
-}
-
 // Converts a geom Polygon to slice of s2 Loop.
 //
+// Rings with no area, or with fewer than 3 distinct points, don't have an
+// orientation, so the checks below can't tell which side of them is inside.
+// They are skipped, along with the holes of an outer ring with no area, unless
+// opts.rejectDegenerate is set in which case they give an error.
+//
 // Modified from types.loopFromPolygon from github.com/dgraph-io/dgraph.
-func loopSliceFromPolygon(p orb.Polygon) ([]*s2.Loop, error) {
+func loopSliceFromPolygon(p orb.Polygon, opts options) ([]*s2.Loop, error) {
 	loops := make([]*s2.Loop, 0, len(p))
 
 	for i := 0; i < len(p); i++ {
@@ -392,16 +914,28 @@ func loopSliceFromPolygon(p orb.Polygon) ([]*s2.Loop, error) {
 				"last coordinate not same as first for polygon: %+v", p)
 		}
 
-		// S2 specifies that the orientation of the polygons should be CCW.
-		// However there is no restriction on the orientation in WKB (or
-		// GeoJSON). To get the correct orientation we assume that the polygons
-		// are always less than one hemisphere. If they are bigger, we flip the
-		// orientation.
-		reverse := isClockwise(r)
-		l := loopFromRing(r, reverse)
+		l := loopFromRing(r, isClockwise(r))
+
+		if r.Orientation() == 0 || l.NumVertices() < 3 {
+			if opts.rejectDegenerate {
+				return nil, fmt.Errorf("ring %d: %w", i, ErrDegenerateRing)
+			}
 
-		// Since our clockwise check was approximate, we check the cap and
-		// reverse if needed.
+			if i == 0 {
+				return nil, nil
+			}
+
+			continue
+		}
+
+		if opts.rfc7946Winding && !hasRFC7946Winding(r, i > 0) {
+			return nil, fmt.Errorf("ring %d: %w", i, ErrWrongWinding)
+		}
+
+		// The planar orientation is wrong for rings crossing the antimeridian
+		// or around a pole, which leaves the loop covering the rest of the
+		// world instead. Nothing in the datasets is bigger than a hemisphere,
+		// so those are inverted.
 		if l.CapBound().Radius().Degrees() > 90 {
 			// Remaking the loop sometimes caused problems, this works better
 			l.Invert()
@@ -413,10 +947,25 @@ func loopSliceFromPolygon(p orb.Polygon) ([]*s2.Loop, error) {
 	return loops, nil
 }
 
-// Checks if a ring is clockwise or counter-clockwise. Note: This uses the
-// algorithm for planar polygons and doesn't work for spherical polygons that
-// contain the poles or the antimeridan discontinuity. We use this as a fast
-// approximation instead.
+// hasRFC7946Winding reports whether r follows the right hand rule from RFC
+// 7946, exterior rings counterclockwise and holes clockwise.
+func hasRFC7946Winding(r orb.Ring, hole bool) bool {
+	if hole {
+		return r.Orientation() == orb.CW
+	}
+
+	return r.Orientation() == orb.CCW
+}
+
+// Checks if a ring is clockwise or counter-clockwise. s2 needs every loop,
+// including holes, to be counter-clockwise, so this is used to normalise the
+// winding of all rings whatever the input uses. RFC 7946 has exterior rings
+// counter-clockwise and holes clockwise, but says parsers shouldn't reject
+// other windings, and the Natural Earth data has clockwise exterior rings.
+//
+// Note: This uses the algorithm for planar polygons and doesn't work for
+// spherical polygons that contain the poles or the antimeridan discontinuity,
+// see loopSliceFromPolygon for how those are handled.
 //
 // From github.com/dgraph-io/dgraph
 func isClockwise(r orb.Ring) bool {
@@ -441,8 +990,11 @@ func loopFromRing(r orb.Ring, reverse bool) *s2.Loop {
 	// In WKB, the last coordinate is repeated for a ring to form a closed loop.
 	// For s2 the points aren't allowed to repeat and the loop is assumed to be
 	// closed, so we skip the last point.
+	//
+	// Repeated points aren't allowed anywhere else either, so they're dropped,
+	// which can leave fewer than 3 points for the caller to deal with.
 	n := len(r)
-	pts := make([]s2.Point, n-1)
+	pts := make([]s2.Point, 0, n-1)
 
 	for i := 0; i < n-1; i++ {
 		var c orb.Point
@@ -452,7 +1004,16 @@ func loopFromRing(r orb.Ring, reverse bool) *s2.Loop {
 			c = r[i]
 		}
 
-		pts[i] = pointFromCoord(c)
+		pt := pointFromCoord(c)
+		if len(pts) > 0 && pts[len(pts)-1] == pt {
+			continue
+		}
+
+		pts = append(pts, pt)
+	}
+
+	for len(pts) > 1 && pts[len(pts)-1] == pts[0] {
+		pts = pts[:len(pts)-1]
 	}
 
 	return s2.LoopFromPoints(pts)
@@ -25,6 +25,7 @@ import (
 	"testing"
 
 	"github.com/go-test/deep"
+	"github.com/paulmach/orb/geojson"
 )
 
 var testdata = []struct {
@@ -32,55 +33,78 @@ var testdata = []struct {
 	in       []float64
 	err      error
 	expected Location
+
+	// FeatureIDs of the matched shapes in the admin-0, admin-1 and admin-2
+	// datasets, the expected Location has the most specific one that is
+	// loaded.
+	countryID, provinceID, countyID string
 }{
 	{
 		name: "Algeria",
 		in:   []float64{1.880273, 31.787305},
 		err:  nil,
 		expected: Location{
-			Country:      "Algeria",
-			CountryLong:  "People's Democratic Republic of Algeria",
-			CountryCode2: "DZ",
-			CountryCode3: "DZA",
-			Continent:    "Africa",
-			Region:       "Africa",
-			SubRegion:    "Northern Africa",
-			Province:     "El Bayadh",
-			ProvinceCode: "DZ-32",
+			Country:            "Algeria",
+			CountryLong:        "People's Democratic Republic of Algeria",
+			Sovereign:          "Algeria",
+			CountryCode2:       "DZ",
+			CountryCode3:       "DZA",
+			CountryCodeNumeric: "012",
+			Continent:          "Africa",
+			Region:             "Africa",
+			SubRegion:          "Northern Africa",
+			RegionCode:         "002",
+			SubRegionCode:      "015",
+			Province:           "El Bayadh",
+			ProvinceCode:       "DZ-32",
 		},
+		countryID:  "1159320565",
+		provinceID: "1159311947",
 	},
 	{
 		name: "Madagascar",
 		in:   []float64{47.523836, -18.905691},
 		err:  nil,
 		expected: Location{
-			Country:      "Madagascar",
-			CountryLong:  "Republic of Madagascar",
-			CountryCode2: "MG",
-			CountryCode3: "MDG",
-			Continent:    "Africa",
-			Region:       "Africa",
-			SubRegion:    "Eastern Africa",
-			Province:     "Analamanga",
-			ProvinceCode: "MG-T",
-			City:         "Antananarivo",
+			Country:            "Madagascar",
+			CountryLong:        "Republic of Madagascar",
+			Sovereign:          "Madagascar",
+			CountryCode2:       "MG",
+			CountryCode3:       "MDG",
+			CountryCodeNumeric: "450",
+			Continent:          "Africa",
+			Region:             "Africa",
+			SubRegion:          "Eastern Africa",
+			RegionCode:         "002",
+			SubRegionCode:      "014",
+			Province:           "Analamanga",
+			ProvinceCode:       "MG-T",
+			City:               "Antananarivo",
 		},
+		countryID:  "1159321051",
+		provinceID: "1159317851",
 	},
 	{
 		name: "Zimbabwe",
 		in:   []float64{29.832875, -19.948725},
 		err:  nil,
 		expected: Location{
-			Country:      "Zimbabwe",
-			CountryLong:  "Republic of Zimbabwe",
-			CountryCode2: "ZW",
-			CountryCode3: "ZWE",
-			Continent:    "Africa",
-			Region:       "Africa",
-			SubRegion:    "Eastern Africa",
-			Province:     "Midlands",
-			ProvinceCode: "ZW-MI",
+			Country:            "Zimbabwe",
+			CountryLong:        "Republic of Zimbabwe",
+			Sovereign:          "Zimbabwe",
+			CountryCode2:       "ZW",
+			CountryCode3:       "ZWE",
+			CountryCodeNumeric: "716",
+			Continent:          "Africa",
+			Region:             "Africa",
+			SubRegion:          "Eastern Africa",
+			RegionCode:         "002",
+			SubRegionCode:      "014",
+			Province:           "Midlands",
+			ProvinceCode:       "ZW-MI",
 		},
+		countryID:  "1159321441",
+		provinceID: "1159307823",
 	},
 	{
 		name:     "Ocean",
@@ -99,133 +123,185 @@ var testdata = []struct {
 		in:   []float64{44.99, -89.99},
 		err:  nil,
 		expected: Location{
-			Country:      "Antarctica",
-			CountryLong:  "",
-			CountryCode2: "AQ",
-			CountryCode3: "ATA",
-			Continent:    "Antarctica",
-			Region:       "Antarctica",
-			SubRegion:    "Antarctica",
-			Province:     "Antarctica",
-			ProvinceCode: "AQ-X01~",
+			Country:            "Antarctica",
+			CountryLong:        "",
+			Sovereign:          "Antarctica",
+			CountryCode2:       "AQ",
+			CountryCode3:       "ATA",
+			CountryCodeNumeric: "010",
+			Continent:          "Antarctica",
+			Region:             "Antarctica",
+			SubRegion:          "Antarctica",
+			Province:           "Antarctica",
+			ProvinceCode:       "AQ-X01~",
 		},
+		countryID:  "1159320335",
+		provinceID: "1159315599",
 	},
 	{
 		name: "Alaska",
 		in:   []float64{-149.901785, 61.199134},
 		err:  nil,
 		expected: Location{
-			Country:      "United States of America",
-			CountryLong:  "United States of America",
-			CountryCode2: "US",
-			CountryCode3: "USA",
-			Continent:    "North America",
-			Region:       "Americas",
-			SubRegion:    "Northern America",
-			Province:     "Alaska",
-			ProvinceCode: "US-AK",
-			County:       "", // unknown
-			City:         "Anchorage",
+			Country:            "United States of America",
+			CountryLong:        "United States of America",
+			Sovereign:          "United States of America",
+			CountryCode2:       "US",
+			CountryCode3:       "USA",
+			CountryCodeNumeric: "840",
+			Continent:          "North America",
+			Region:             "Americas",
+			SubRegion:          "Northern America",
+			RegionCode:         "019",
+			SubRegionCode:      "021",
+			Province:           "Alaska",
+			ProvinceCode:       "US-AK",
+			County:             "Anchorage",
+			CountyCode:         "USA-02020",
+			City:               "Anchorage",
 		},
+		countryID:  "1159321369",
+		provinceID: "1159308731",
+		countyID:   "1730096393",
 	},
 	{
 		name: "UK",
 		in:   []float64{0, 51.5045},
 		err:  nil,
 		expected: Location{
-			Country:      "United Kingdom",
-			CountryLong:  "United Kingdom of Great Britain and Northern Ireland",
-			CountryCode2: "GB",
-			CountryCode3: "GBR",
-			Continent:    "Europe",
-			Region:       "Europe",
-			SubRegion:    "Northern Europe",
-			Province:     "Tower Hamlets",
-			ProvinceCode: "GB-TWH",
-			City:         "London",
+			Country:            "United Kingdom",
+			CountryLong:        "United Kingdom of Great Britain and Northern Ireland",
+			Sovereign:          "United Kingdom",
+			CountryCode2:       "GB",
+			CountryCode3:       "GBR",
+			CountryCodeNumeric: "826",
+			Continent:          "Europe",
+			Region:             "Europe",
+			SubRegion:          "Northern Europe",
+			RegionCode:         "150",
+			SubRegionCode:      "154",
+			Province:           "Tower Hamlets",
+			ProvinceCode:       "GB-TWH",
+			City:               "London",
 		},
+		countryID:  "1159320713",
+		provinceID: "1159312973",
 	},
 	{
 		name: "Libya",
 		in:   []float64{24.98, 25.86},
 		err:  nil,
 		expected: Location{
-			Country:      "Libya",
-			CountryLong:  "Libya",
-			CountryCode2: "LY",
-			CountryCode3: "LBY",
-			Continent:    "Africa",
-			Region:       "Africa",
-			SubRegion:    "Northern Africa",
-			Province:     "Al Kufrah",
-			ProvinceCode: "LY-KF",
+			Country:            "Libya",
+			CountryLong:        "Libya",
+			Sovereign:          "Libya",
+			CountryCode2:       "LY",
+			CountryCode3:       "LBY",
+			CountryCodeNumeric: "434",
+			Continent:          "Africa",
+			Region:             "Africa",
+			SubRegion:          "Northern Africa",
+			RegionCode:         "002",
+			SubRegionCode:      "015",
+			Province:           "Al Kufrah",
+			ProvinceCode:       "LY-KF",
 		},
+		countryID:  "1159321017",
+		provinceID: "1159314113",
 	},
 	{
 		name: "Egypt",
 		in:   []float64{25.005187, 25.855963},
 		err:  nil,
 		expected: Location{
-			Country:      "Egypt",
-			CountryLong:  "Arab Republic of Egypt",
-			CountryCode2: "EG",
-			CountryCode3: "EGY",
-			Continent:    "Africa",
-			Region:       "Africa",
-			SubRegion:    "Northern Africa",
-			Province:     "Al Wadi at Jadid",
-			ProvinceCode: "EG-WAD",
+			Country:            "Egypt",
+			CountryLong:        "Arab Republic of Egypt",
+			Sovereign:          "Egypt",
+			CountryCode2:       "EG",
+			CountryCode3:       "EGY",
+			CountryCodeNumeric: "818",
+			Continent:          "Africa",
+			Region:             "Africa",
+			SubRegion:          "Northern Africa",
+			RegionCode:         "002",
+			SubRegionCode:      "015",
+			Province:           "Al Wadi at Jadid",
+			ProvinceCode:       "EG-WAD",
 		},
+		countryID:  "1159320575",
+		provinceID: "1159310489",
 	},
 	{
 		name: "US Border",
 		in:   []float64{-102.560616, 48.992073},
 		err:  nil,
 		expected: Location{
-			Country:      "United States of America",
-			CountryLong:  "United States of America",
-			CountryCode2: "US",
-			CountryCode3: "USA",
-			Continent:    "North America",
-			Region:       "Americas",
-			SubRegion:    "Northern America",
-			Province:     "North Dakota",
-			ProvinceCode: "US-ND",
-			County:       "Burke",
+			Country:            "United States of America",
+			CountryLong:        "United States of America",
+			Sovereign:          "United States of America",
+			CountryCode2:       "US",
+			CountryCode3:       "USA",
+			CountryCodeNumeric: "840",
+			Continent:          "North America",
+			Region:             "Americas",
+			SubRegion:          "Northern America",
+			RegionCode:         "019",
+			SubRegionCode:      "021",
+			Province:           "North Dakota",
+			ProvinceCode:       "US-ND",
+			County:             "Burke",
+			CountyCode:         "USA-38013",
 		},
+		countryID:  "1159321369",
+		provinceID: "1159315337",
+		countyID:   "1730093821",
 	},
 	{
 		name: "Canada Border",
 		in:   []float64{-102.560616, 49.02},
 		err:  nil,
 		expected: Location{
-			Country:      "Canada",
-			CountryLong:  "Canada",
-			CountryCode2: "CA",
-			CountryCode3: "CAN",
-			Continent:    "North America",
-			Region:       "Americas",
-			SubRegion:    "Northern America",
-			Province:     "Saskatchewan",
-			ProvinceCode: "CA-SK",
+			Country:            "Canada",
+			CountryLong:        "Canada",
+			Sovereign:          "Canada",
+			CountryCode2:       "CA",
+			CountryCode3:       "CAN",
+			CountryCodeNumeric: "124",
+			Continent:          "North America",
+			Region:             "Americas",
+			SubRegion:          "Northern America",
+			RegionCode:         "019",
+			SubRegionCode:      "021",
+			Province:           "Saskatchewan",
+			ProvinceCode:       "CA-SK",
 		},
+		countryID:  "1159320467",
+		provinceID: "1159308773",
 	},
 	{
 		name: "Stevens County",
 		in:   []float64{-117.843, 48.392},
 		err:  nil,
 		expected: Location{
-			Country:      "United States of America",
-			CountryLong:  "United States of America",
-			CountryCode2: "US",
-			CountryCode3: "USA",
-			Continent:    "North America",
-			Region:       "Americas",
-			SubRegion:    "Northern America",
-			Province:     "Washington",
-			ProvinceCode: "US-WA",
-			County:       "Stevens",
+			Country:            "United States of America",
+			CountryLong:        "United States of America",
+			Sovereign:          "United States of America",
+			CountryCode2:       "US",
+			CountryCode3:       "USA",
+			CountryCodeNumeric: "840",
+			Continent:          "North America",
+			Region:             "Americas",
+			SubRegion:          "Northern America",
+			RegionCode:         "019",
+			SubRegionCode:      "021",
+			Province:           "Washington",
+			ProvinceCode:       "US-WA",
+			County:             "Stevens",
+			CountyCode:         "USA-53065",
 		},
+		countryID:  "1159321369",
+		provinceID: "1159309547",
+		countyID:   "1730095899",
 	},
 }
 
@@ -266,6 +342,140 @@ func TestDatasetNames(t *testing.T) {
 	}
 }
 
+// squareData is a named dataset function, for checking the dataset names of
+// method values.
+type squareData struct{ t *testing.T }
+
+func (d squareData) Square() []byte { return compressData(d.t, squareGeo) }
+
+func TestDatasetNames_LoadingPaths(t *testing.T) {
+	square := func() []byte { return compressData(t, squareGeo) }
+
+	t.Run("funcs", func(t *testing.T) {
+		r, err := New(square, squareData{t}.Square, square)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := []string{
+			"github.com/sams96/rgeo.TestDatasetNames_LoadingPaths.func1",
+			"github.com/sams96/rgeo.squareData.Square",
+		}
+		if diff := deep.Equal(expected, r.DatasetNames()); diff != nil {
+			t.Error(diff)
+		}
+
+		for _, name := range r.DatasetNames() {
+			if _, err := r.GetGeometry(orb.Point{0.5, 52.5}, name); err != nil {
+				t.Errorf("%s: %s", name, err)
+			}
+		}
+	})
+
+	t.Run("filtered", func(t *testing.T) {
+		none := WithFeatureFilter(func(map[string]interface{}) bool { return false })
+		r, err := NewWithOptions([]func() []byte{square}, none)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		expected := []string{getFunctionName(square)}
+		if diff := deep.Equal(expected, r.DatasetNames()); diff != nil {
+			t.Error(diff)
+		}
+	})
+
+	t.Run("feature collection", func(t *testing.T) {
+		fc := geojson.NewFeatureCollection()
+		fc.Append(geojson.NewFeature(orb.Polygon{{{0, 52}, {1, 52}, {1, 53}, {0, 53}, {0, 52}}}))
+
+		r, err := NewFromFeatureCollection(fc, "square")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := deep.Equal([]string{"square"}, r.DatasetNames()); diff != nil {
+			t.Error(diff)
+		}
+
+		// The returned slice mustn't be shared.
+		r.DatasetNames()[0] = "changed"
+		if diff := deep.Equal([]string{"square"}, r.DatasetNames()); diff != nil {
+			t.Error(diff)
+		}
+
+		if _, err := NewFromFeatureCollection(fc, ""); err == nil {
+			t.Error("expected error for empty dataset name")
+		}
+	})
+}
+
+func TestDatasetsContaining(t *testing.T) {
+	square := func() []byte { return compressData(t, squareGeo) }
+	west := func() []byte { return compressData(t, twoSquaresGeo) }
+	westAgain := func() []byte { return compressData(t, twoSquaresGeo) }
+
+	r, err := New(westAgain, square, west)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := []string{getFunctionName(west), getFunctionName(westAgain)}
+	if diff := deep.Equal(expected, r.DatasetsContaining(orb.Point{0.5, 0.5})); diff != nil {
+		t.Error(diff)
+	}
+	if diff := deep.Equal([]string{getFunctionName(square)}, r.DatasetsContaining(orb.Point{0.5, 52.5})); diff != nil {
+		t.Error(diff)
+	}
+	if got := r.DatasetsContaining(orb.Point{1.5, 0.5}); len(got) != 0 {
+		t.Errorf("expected no datasets, got: %v", got)
+	}
+}
+
+func TestReverseGeocodeExcluding(t *testing.T) {
+	cityGeo := `{
+		"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"name_conve":"Westville"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0.2,0.2],[0.8,0.2],[0.8,0.8],[0.2,0.8],[0.2,0.2]]]}}
+		]
+	}`
+
+	countries := func() []byte { return compressData(t, twoSquaresGeo) }
+	cities := func() []byte { return compressData(t, cityGeo) }
+
+	r, err := New(countries, cities)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := r.ReverseGeocodeExcluding(orb.Point{0.5, 0.5}, getFunctionName(cities))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(Location{Country: "West", CountryCode3: "WST"}, got); diff != nil {
+		t.Error(diff)
+	}
+
+	// Without any exclusions it's the same as ReverseGeocode.
+	got, err = r.ReverseGeocodeExcluding(orb.Point{0.5, 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(Location{Country: "West", CountryCode3: "WST", City: "Westville"}, got); diff != nil {
+		t.Error(diff)
+	}
+
+	if _, err := r.ReverseGeocodeExcluding(orb.Point{0.5, 0.5}, getFunctionName(countries), getFunctionName(cities)); !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("expected error: %s\n got: %s\n", ErrLocationNotFound, err)
+	}
+
+	if _, err := r.ReverseGeocodeExcluding(orb.Point{0.5, 0.5}, "Cities10"); err == nil || errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("expected error for unknown dataset, got: %v", err)
+	}
+}
+
 func TestReverseGeocode(t *testing.T) {
 	testgeo := `{
 		"type":"FeatureCollection",
@@ -350,7 +560,9 @@ func TestReverseGeocode_Countries(t *testing.T) {
 			test.expected.Province = ""
 			test.expected.ProvinceCode = ""
 			test.expected.County = ""
+			test.expected.CountyCode = ""
 			test.expected.City = ""
+			test.expected.FeatureID = test.countryID
 
 			t.Run(test.name, func(t *testing.T) {
 				result, err := r.ReverseGeocode(orb.Point{test.in[0], test.in[1]})
@@ -388,7 +600,9 @@ func TestReverseGeocode_Provinces(t *testing.T) {
 		test := test
 
 		test.expected.County = ""
+		test.expected.CountyCode = ""
 		test.expected.City = ""
+		test.expected.FeatureID = test.provinceID
 
 		t.Run(test.name, func(t *testing.T) {
 			result, err := r.ReverseGeocode(orb.Point{test.in[0], test.in[1]})
@@ -416,6 +630,7 @@ func TestReverseGeocode_Counties(t *testing.T) {
 		test := test
 
 		test.expected.City = ""
+		test.expected.FeatureID = firstNonEmpty(test.countyID, test.provinceID)
 
 		t.Run(test.name, func(t *testing.T) {
 			result, err := r.ReverseGeocode(orb.Point{test.in[0], test.in[1]})
@@ -443,6 +658,8 @@ func TestReverseGeocode_Cities(t *testing.T) {
 		test := test
 
 		test.expected.County = ""
+		test.expected.CountyCode = ""
+		test.expected.FeatureID = test.provinceID
 
 		t.Run(test.name, func(t *testing.T) {
 			result, err := r.ReverseGeocode(orb.Point{test.in[0], test.in[1]})
@@ -538,6 +755,271 @@ func TestNew_BadData(t *testing.T) {
 	}
 }
 
+func TestNew_DegenerateRings(t *testing.T) {
+	// A collinear ring on its own, and a square with a collinear hole.
+	const degenerateGeo = `{
+	"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"ISO_A3":"LIN"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[10,10],[11,11],[12,12],[10,10]]]}},
+			{"type":"Feature",
+			"properties":{"ISO_A3":"SQR"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[
+					[[0,0],[2,0],[2,2],[0,2],[0,0]],
+					[[0.5,0.5],[1,1],[1.5,1.5],[0.5,0.5]]]}}
+		]
+	}`
+
+	data := func() []byte { return compressData(t, degenerateGeo) }
+
+	r, err := New(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if l, err := r.ReverseGeocode(orb.Point{1, 1.5}); err != nil || l.CountryCode3 != "SQR" {
+		t.Errorf("expected SQR, got: %v, %v", l, err)
+	}
+	if l, err := r.ReverseGeocode(orb.Point{50, 50}); !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("expected the collinear ring to be skipped, got: %v, %v", l, err)
+	}
+
+	_, err = NewWithOptions([]func() []byte{data}, RejectDegenerateRings())
+	if !errors.Is(err, ErrDegenerateRing) {
+		t.Errorf("expected error: %s\n got: %s\n", ErrDegenerateRing, err)
+	}
+}
+
+func TestNew_MissingGeometry(t *testing.T) {
+	// A metadata only feature between two countries.
+	const missingGeo = `{
+	"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"ISO_A3":"WST"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}},
+			{"type":"Feature",
+			"properties":{"ISO_A3":"NUL"},
+			"geometry":null},
+			{"type":"Feature",
+			"properties":{"ISO_A3":"EST"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[2,0],[3,0],[3,1],[2,1],[2,0]]]}}
+		]
+	}`
+
+	data := func() []byte { return compressData(t, missingGeo) }
+
+	r, err := New(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, test := range []struct {
+		in       orb.Point
+		expected string
+	}{{orb.Point{0.5, 0.5}, "WST"}, {orb.Point{2.5, 0.5}, "EST"}} {
+		if l, err := r.ReverseGeocode(test.in); err != nil || l.CountryCode3 != test.expected {
+			t.Errorf("expected %s, got: %v, %v", test.expected, l, err)
+		}
+	}
+
+	shapes, err := r.DatasetShapes(getFunctionName(data))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(shapes) != 2 {
+		t.Errorf("expected the null geometry to be skipped, got %d shapes", len(shapes))
+	}
+
+	_, err = NewWithOptions([]func() []byte{data}, RejectMissingGeometry())
+	if !errors.Is(err, ErrMissingGeometry) {
+		t.Errorf("expected error: %s\n got: %s\n", ErrMissingGeometry, err)
+	}
+}
+
+func TestNew_Winding(t *testing.T) {
+	square := orb.Ring{{0, 0}, {4, 0}, {4, 4}, {0, 4}, {0, 0}}
+	hole := orb.Ring{{1, 1}, {3, 1}, {3, 3}, {1, 3}, {1, 1}}
+	reversed := func(r orb.Ring) orb.Ring {
+		r = r.Clone()
+		r.Reverse()
+		return r
+	}
+
+	// square and hole are both counterclockwise.
+	tests := []struct {
+		name    string
+		polygon orb.Polygon
+		rfc7946 bool
+	}{
+		{name: "RFC 7946", polygon: orb.Polygon{square, reversed(hole)}, rfc7946: true},
+		{name: "Reversed", polygon: orb.Polygon{reversed(square), hole}},
+		{name: "All counterclockwise", polygon: orb.Polygon{square, hole}},
+		{name: "All clockwise", polygon: orb.Polygon{reversed(square), reversed(hole)}},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			fc := geojson.NewFeatureCollection()
+			fc.Append(geojson.NewFeature(test.polygon))
+			fc.Features[0].Properties["ISO_A3"] = "SQR"
+
+			r, err := NewFromFeatureCollection(fc, "test")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if l, err := r.ReverseGeocode(orb.Point{0.5, 0.5}); err != nil || l.CountryCode3 != "SQR" {
+				t.Errorf("expected SQR, got: %v, %v", l, err)
+			}
+			for _, p := range []orb.Point{{2, 2}, {10, 10}, {-170, -45}} {
+				if l, err := r.ReverseGeocode(p); !errors.Is(err, ErrLocationNotFound) {
+					t.Errorf("%v: expected error: %s\n got: %v, %v\n", p, ErrLocationNotFound, l, err)
+				}
+			}
+
+			_, err = NewFromFeatureCollection(fc, "test", RequireRFC7946Winding())
+			if test.rfc7946 && err != nil {
+				t.Errorf("expected no error, got: %s", err)
+			}
+			if !test.rfc7946 && !errors.Is(err, ErrWrongWinding) {
+				t.Errorf("expected error: %s\n got: %s\n", ErrWrongWinding, err)
+			}
+		})
+	}
+}
+
+func TestNewFromFeatureCollection(t *testing.T) {
+	fc := geojson.NewFeatureCollection()
+	fc.Append(geojson.NewFeature(orb.Polygon{{{0, 52}, {1, 52}, {1, 53}, {0, 53}, {0, 52}}}))
+	fc.Features[0].Properties["ISO_A3"] = "TST"
+
+	r, err := NewFromFeatureCollection(fc, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := r.ReverseGeocode(orb.Point{0.5, 52.5})
+	if err != nil {
+		t.Error(err)
+	}
+	if diff := deep.Equal(Location{CountryCode3: "TST"}, result); diff != nil {
+		t.Error(diff)
+	}
+
+	if diff := deep.Equal([]string{"test"}, r.DatasetNames()); diff != nil {
+		t.Error(diff)
+	}
+
+	bad := geojson.NewFeatureCollection()
+	bad.Append(geojson.NewFeature(orb.Point{0, 0}))
+	_, err = NewFromFeatureCollection(bad, "bad")
+	expected := "bad polygon in geometry: needs Polygon or MultiPolygon"
+	if err == nil || err.Error() != expected {
+		t.Errorf("expected error: %s\n got: %s\n", expected, err)
+	}
+}
+
+func TestReverseGeocode_Sovereign(t *testing.T) {
+	fc := geojson.NewFeatureCollection()
+	fc.Append(geojson.NewFeature(orb.Polygon{{{-67, 18}, {-65, 18}, {-65, 19}, {-67, 19}, {-67, 18}}}))
+	fc.Features[0].Properties["ADMIN"] = "Puerto Rico"
+	fc.Features[0].Properties["SOVEREIGNT"] = "United States of America"
+
+	r, err := NewFromFeatureCollection(fc, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := r.ReverseGeocode(orb.Point{-66, 18.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	expected := Location{Country: "Puerto Rico", Sovereign: "United States of America"}
+	if diff := deep.Equal(expected, result); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestGetISONumeric(t *testing.T) {
+	tests := []struct {
+		name     string
+		in       map[string]interface{}
+		expected string
+	}{
+		{"ISO_N3", map[string]interface{}{"ISO_N3": "840", "ISO_N3_EH": "840"}, "840"},
+		{"ISO_N3_EH", map[string]interface{}{"ISO_N3": "-99", "ISO_N3_EH": "250"}, "250"},
+		{"Neither", map[string]interface{}{"ISO_N3": "-99", "ISO_N3_EH": "-99"}, ""},
+		{"Missing", map[string]interface{}{}, ""},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if got := getISONumeric(test.in); got != test.expected {
+				t.Errorf("expected: %q\n got: %q\n", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestGetLocationStrings_Admin2(t *testing.T) {
+	admin2 := func(typ string) map[string]interface{} {
+		return map[string]interface{}{
+			"ADMIN":      "United States of America",
+			"FEATURECLA": "Admin-2 scale rank",
+			"TYPE":       typ,
+			"NAME":       "Orleans",
+			"ADM2_CODE":  "USA-22071",
+		}
+	}
+
+	expected := Location{Country: "United States of America", County: "Orleans", CountyCode: "USA-22071"}
+	for _, typ := range []string{"County", "Parish", "Borough"} {
+		if diff := deep.Equal(expected, getLocationStrings(admin2(typ))); diff != nil {
+			t.Errorf("%s: %v", typ, diff)
+		}
+	}
+
+	country := map[string]interface{}{"ADMIN": "France", "NAME": "France", "TYPE": "Country"}
+	if diff := deep.Equal(Location{Country: "France"}, getLocationStrings(country)); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestGetFeatureID(t *testing.T) {
+	tests := []struct {
+		name     string
+		props    map[string]interface{}
+		keys     []string
+		expected string
+	}{
+		{"Number", map[string]interface{}{"NE_ID": 1159320565.0}, nil, "1159320565"},
+		{"Lowercase", map[string]interface{}{"ne_id": 1159311947.0}, nil, "1159311947"},
+		{"Missing", map[string]interface{}{"ADMIN": "France"}, nil, ""},
+		{"String", map[string]interface{}{"id": "abc-1"}, []string{"id"}, "abc-1"},
+		{"Fallback", map[string]interface{}{"id": "", "gid": 7.5}, []string{"id", "gid"}, "7.5"},
+		{"OtherType", map[string]interface{}{"id": true}, []string{"id"}, ""},
+		{"NoKeys", map[string]interface{}{"NE_ID": 1.0}, []string{}, ""},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if got := getFeatureID(test.props, test.keys); got != test.expected {
+				t.Errorf("expected: %q, got: %q", test.expected, got)
+			}
+		})
+	}
+}
+
 func TestString(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -705,6 +1187,29 @@ func BenchmarkReverseGeocode_City10(b *testing.B) {
 	}
 }
 
+// BenchmarkReverseGeocode_Ocean10 only looks up points which aren't in any
+// country, these are rejected by the index without checking any shapes.
+func BenchmarkReverseGeocode_Ocean10(b *testing.B) {
+	r, err := New(Countries10)
+	if err != nil {
+		b.Error(err)
+	}
+
+	var points []orb.Point
+	for len(points) < 1000 {
+		p := orb.Point{(rand.Float64() * 360) - 180, (rand.Float64() * 180) - 90}
+		if _, err := r.ReverseGeocode(p); errors.Is(err, ErrLocationNotFound) {
+			points = append(points, p)
+		}
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		_, _ = r.ReverseGeocode(points[i%len(points)])
+	}
+}
+
 func BenchmarkNew(b *testing.B) {
 	for i := 0; i < b.N; i++ {
 		_, err := New(Countries110)
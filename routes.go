@@ -0,0 +1,155 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"fmt"
+
+	"github.com/paulmach/orb"
+)
+
+// CommonBorders returns the countries that both paths go through, e.g. to
+// compare the countries visited on two trips. The paths are followed as in
+// PathMatches, so a country counts if a path only touches its border, and the
+// countries are in the order path a reaches them.
+//
+// Countries are compared by their country codes and names rather than by their
+// shapes, so a country found in different datasets is the same country. Two
+// shapes are the same country if they share any of CountryCode3, CountryCode2,
+// CountryCodeNumeric or Country, counting only the codes which aren't "-99",
+// along with any other shape that does on either path. So a province or county
+// without any codes, like those in Provinces10, is the same country as the
+// country shape around it with the same Country. Each Location only has the
+// country fields, as in LevelCountry, filled in from every shape of the
+// country path a goes through.
+//
+// If the paths don't have any countries in common it returns an empty slice,
+// and if either of them doesn't go through any of the loaded shapes it returns
+// ErrLocationNotFound.
+func (r *Rgeo) CommonBorders(a, b orb.LineString) ([]Location, error) {
+	matchesA, err := r.PathMatches(a)
+	if err != nil {
+		return nil, fmt.Errorf("path a: %w", err)
+	}
+
+	matchesB, err := r.PathMatches(b)
+	if err != nil {
+		return nil, fmt.Errorf("path b: %w", err)
+	}
+
+	keys := make(countryKeys)
+	for _, matches := range [][]Match{matchesA, matchesB} {
+		for _, m := range matches {
+			keys.add(m.Location)
+		}
+	}
+
+	countriesB := keys.countries(matchesB)
+	inB := make(map[string]bool, len(countriesB))
+	for _, c := range countriesB {
+		inB[keys.key(c)] = true
+	}
+
+	ret := []Location{}
+	for _, c := range keys.countries(matchesA) {
+		if inB[keys.key(c)] {
+			ret = append(ret, c)
+		}
+	}
+
+	return ret, nil
+}
+
+// countryKeys joins up the codes and names of countries which belong to the
+// same country, for CommonBorders. It maps each one to another one of the same
+// country, with the one they all lead to as the key of the country.
+type countryKeys map[string]string
+
+// aliases returns the codes and name of the country of l, each with a prefix
+// so that different kinds of code can't be mixed up.
+func (countryKeys) aliases(l Location) []string {
+	var ret []string
+	for i, code := range []string{l.CountryCode3, l.CountryCode2, l.CountryCodeNumeric} {
+		if code = firstValidCode(code); code != "" {
+			ret = append(ret, [...]string{"a3:", "a2:", "n3:"}[i]+code)
+		}
+	}
+
+	if l.Country != "" {
+		ret = append(ret, "name:"+l.Country)
+	}
+
+	return ret
+}
+
+// add joins up all of the aliases of l.
+func (k countryKeys) add(l Location) {
+	aliases := k.aliases(l)
+	for _, alias := range aliases {
+		if _, ok := k[alias]; !ok {
+			k[alias] = alias
+		}
+
+		if a, b := k.find(aliases[0]), k.find(alias); a != b {
+			k[b] = a
+		}
+	}
+}
+
+// find returns the key of the country alias belongs to.
+func (k countryKeys) find(alias string) string {
+	for k[alias] != alias {
+		alias = k[alias]
+	}
+
+	return alias
+}
+
+// key returns the key of the country of l, which has to have been added, or ""
+// for a Location without a country.
+func (k countryKeys) key(l Location) string {
+	aliases := k.aliases(l)
+	if len(aliases) == 0 {
+		return ""
+	}
+
+	return k.find(aliases[0])
+}
+
+// countries returns the countries of the matches, once each in the order they
+// come, with only their country fields.
+func (k countryKeys) countries(matches []Match) []Location {
+	pos := make(map[string]int)
+	var ret []Location
+	for _, m := range matches {
+		loc := m.Location.only(levelFields[LevelCountry])
+
+		key := k.key(loc)
+		if key == "" {
+			continue
+		}
+
+		if i, ok := pos[key]; ok {
+			ret[i].fillFrom(&loc)
+			continue
+		}
+
+		pos[key] = len(ret)
+		ret = append(ret, loc)
+	}
+
+	return ret
+}
@@ -0,0 +1,158 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+// routesCountryGeo and routesProvinceGeo are a country with only a numeric
+// code, like France in Countries10, and a province of it without any codes,
+// like those in Provinces10.
+const (
+	routesCountryGeo = `{
+	"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"ADMIN":"Land","ISO_A3":"-99","ISO_N3":"250"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,0],[4,0],[4,4],[0,4],[0,0]]]}}
+		]
+	}`
+
+	routesProvinceGeo = `{
+	"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"admin":"Land","name":"North"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,2],[4,2],[4,4],[0,4],[0,2]]]}}
+		]
+	}`
+)
+
+func TestCommonBorders(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, twoSquaresGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Another shape of West, with the same code but a different name, to
+	// check that countries are compared by their codes.
+	err = r.AddFeature(orb.Polygon{{{0, 5}, {1, 5}, {1, 6}, {0, 6}, {0, 5}}},
+		Location{Country: "West Islands", CountryCode3: "WST"}, "islands")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	west := Location{Country: "West", CountryCode3: "WST"}
+	east := Location{Country: "East", CountryCode3: "EST"}
+
+	tests := []struct {
+		name     string
+		a, b     orb.LineString
+		expected []Location
+		err      error
+	}{
+		{
+			name:     "Both",
+			a:        orb.LineString{{0.5, 0.5}, {2.5, 0.5}},
+			b:        orb.LineString{{2.5, 0.8}, {0.5, 0.8}},
+			expected: []Location{west, east},
+		},
+		{
+			name:     "SameCode",
+			a:        orb.LineString{{0.5, 5.5}, {0.8, 5.5}, {2.5, 0.5}},
+			b:        orb.LineString{{0.5, 0.5}, {0.8, 0.5}},
+			expected: []Location{{Country: "West Islands", CountryCode3: "WST"}},
+		},
+		{
+			name:     "Touching",
+			a:        orb.LineString{{1, 1}, {1.5, 1.5}},
+			b:        orb.LineString{{0.5, 0.5}},
+			expected: []Location{west},
+		},
+		{
+			name:     "Nothing in common",
+			a:        orb.LineString{{0.5, 0.5}},
+			b:        orb.LineString{{2.5, 0.5}},
+			expected: []Location{},
+		},
+		{
+			name: "Outside",
+			a:    orb.LineString{{0.5, 0.5}},
+			b:    orb.LineString{{10, 10}, {11, 11}},
+			err:  ErrLocationNotFound,
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := r.CommonBorders(test.a, test.b)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error %v, got %v", test.err, err)
+			}
+
+			if diff := deep.Equal(test.expected, got); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+
+	if _, err := r.CommonBorders(nil, orb.LineString{{0.5, 0.5}}); err == nil {
+		t.Error("expected error for empty path")
+	}
+}
+
+func TestCommonBorders_Provinces(t *testing.T) {
+	r, err := NewWithOptions([]func() []byte{
+		func() []byte { return compressData(t, routesCountryGeo) },
+		func() []byte { return compressData(t, routesProvinceGeo) },
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	land := Location{Country: "Land", CountryCode3: "-99", CountryCodeNumeric: "250"}
+
+	tests := []struct {
+		name string
+		a, b orb.LineString
+	}{
+		{name: "Both in the province", a: orb.LineString{{1, 3}, {3, 3}}, b: orb.LineString{{3, 3.5}, {1, 3.5}}},
+		{name: "One in the province", a: orb.LineString{{1, 3}, {3, 3}}, b: orb.LineString{{1, 1}, {3, 1}}},
+		{name: "Neither in the province", a: orb.LineString{{1, 1}, {3, 1}}, b: orb.LineString{{1, 0.5}, {3, 0.5}}},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := r.CommonBorders(test.a, test.b)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if diff := deep.Equal([]Location{land}, got); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/paulmach/orb"
+)
+
+// ReferencePoint is a coordinate and the country it should be found in, used
+// by SanityCheck.
+type ReferencePoint struct {
+	Name  string
+	Point orb.Point
+
+	// Expected value of Location.Country, or "" if the point shouldn't be in
+	// any country.
+	Country string
+}
+
+// DefaultReferencePoints are the points used by SanityCheck when it isn't
+// given any. They are mostly capital cities, well inside their countries, plus
+// the poles and some points either side of the antimeridian, which are the
+// places that broken polygon orientation tends to show up.
+var DefaultReferencePoints = []ReferencePoint{
+	{Name: "Paris", Point: orb.Point{2.35, 48.86}, Country: "France"},
+	{Name: "Tokyo", Point: orb.Point{139.69, 35.69}, Country: "Japan"},
+	{Name: "Washington D.C.", Point: orb.Point{-77.04, 38.91}, Country: "United States of America"},
+	{Name: "Brasília", Point: orb.Point{-47.88, -15.79}, Country: "Brazil"},
+	{Name: "Nairobi", Point: orb.Point{36.82, -1.29}, Country: "Kenya"},
+	{Name: "Moscow", Point: orb.Point{37.62, 55.76}, Country: "Russia"},
+	{Name: "Canberra", Point: orb.Point{149.13, -35.28}, Country: "Australia"},
+	{Name: "Chukotka (east of antimeridian)", Point: orb.Point{-176, 66.5}, Country: "Russia"},
+	{Name: "Chukotka (west of antimeridian)", Point: orb.Point{172, 65}, Country: "Russia"},
+	{Name: "Vanua Levu, Fiji", Point: orb.Point{179.2, -16.45}, Country: "Fiji"},
+	{Name: "South Pole", Point: orb.Point{44.99, -89.99}, Country: "Antarctica"},
+	{Name: "North Pole", Point: orb.Point{-135, 90}, Country: ""},
+	{Name: "Gulf of Guinea", Point: orb.Point{0, 0}, Country: ""},
+	{Name: "Pacific Ocean", Point: orb.Point{-140, 0}, Country: ""},
+}
+
+// SanityCheck reverse geocodes a set of reference points and returns a
+// description of each one that doesn't give the expected country, so an empty
+// result means everything matched. If no points are given it uses
+// DefaultReferencePoints.
+//
+// This is meant for checking freshly built custom datasets, where polygons
+// with the wrong orientation will often contain most of the world instead of
+// the country they should. The default points only check the Country field,
+// so they need a dataset with country information loaded.
+func (r *Rgeo) SanityCheck(points ...ReferencePoint) []string {
+	if len(points) == 0 {
+		points = DefaultReferencePoints
+	}
+
+	var problems []string
+	for _, p := range points {
+		loc, err := r.reverseGeocode(pointFromCoord(p.Point))
+		if err != nil && !errors.Is(err, ErrLocationNotFound) {
+			problems = append(problems, fmt.Sprintf("%s: %s", p.Name, err))
+			continue
+		}
+
+		if loc.Country != p.Country {
+			problems = append(problems, fmt.Sprintf("%s %v: expected %q, got %q",
+				p.Name, p.Point, p.Country, loc.Country))
+		}
+	}
+
+	return problems
+}
+
+// selfTestPoint is a coordinate and the value one of the Location fields should
+// have there, used by SelfTest for the datasets without country fields.
+type selfTestPoint struct {
+	name     string
+	point    orb.Point
+	expected string
+}
+
+// selfTestDatasets are the bundled datasets and what SelfTest checks in each,
+// the field is one of the Format placeholders. Datasets without any points are
+// checked with DefaultReferencePoints. Each set includes a point across the
+// antimeridian from most of its country and one near a pole.
+var selfTestDatasets = []struct {
+	dataset func() []byte
+	field   string
+	points  []selfTestPoint
+}{
+	{dataset: Countries110},
+	{dataset: Countries10},
+	{dataset: Provinces10, field: "province_code", points: []selfTestPoint{
+		{"Paris", orb.Point{2.35, 48.86}, "FR-75"},
+		{"Seattle", orb.Point{-122.33, 47.61}, "US-WA"},
+		{"Attu Island", orb.Point{173.2, 52.9}, "US-AK"},
+		{"Chukotka (east of antimeridian)", orb.Point{-176, 66.5}, "RU-CHU"},
+		{"Vanua Levu, Fiji", orb.Point{179.2, -16.45}, "FJ-N"},
+		{"Pacific Ocean", orb.Point{-140, 0}, ""},
+	}},
+	{dataset: US_Counties10, field: "county_code", points: []selfTestPoint{
+		{"Seattle", orb.Point{-122.33, 47.61}, "USA-53033"},
+		{"Chicago", orb.Point{-87.63, 41.88}, "USA-17031"},
+		{"Manhattan", orb.Point{-74, 40.71}, "USA-36061"},
+		{"Attu Island", orb.Point{173.2, 52.9}, "USA-02016"},
+		{"Utqiaġvik", orb.Point{-156.78, 71.29}, "USA-02185"},
+		{"Paris", orb.Point{2.35, 48.86}, ""},
+	}},
+	{dataset: Cities10, field: "city", points: []selfTestPoint{
+		{"Paris", orb.Point{2.35, 48.86}, "Paris"},
+		{"Tokyo", orb.Point{139.69, 35.69}, "Tokyo"},
+		{"São Paulo", orb.Point{-46.63, -23.55}, "Sao Paolo"},
+		{"Suva", orb.Point{178.44, -18.14}, "Suva"},
+		{"Apia", orb.Point{-171.76, -13.83}, "Apia"},
+		{"Norilsk", orb.Point{88.2, 69.35}, "Norilsk"},
+		{"Gulf of Guinea", orb.Point{0, 0}, ""},
+	}},
+}
+
+// SelfTest loads each of the bundled datasets in turn and checks that a set of
+// known coordinates give the expected results, e.g. that Paris is in France in
+// Countries10 and in the city of Paris in Cities10. It returns an error
+// listing every coordinate that didn't, or nil if they all did.
+//
+// It's meant for catching a broken build or a bad regeneration of the data, so
+// it's worth running once when deploying, but not on every start: it takes
+// around half a minute, as long as loading all of the datasets. Each one is
+// dropped before loading the next, so it only needs the memory for the
+// largest. Use SanityCheck to check a custom dataset.
+func SelfTest() error {
+	var problems []string
+	for _, ds := range selfTestDatasets {
+		name := strings.TrimPrefix(getFunctionName(ds.dataset), "github.com/sams96/rgeo.")
+
+		r, err := New(ds.dataset)
+		if err != nil {
+			problems = append(problems, fmt.Sprintf("%s: %s", name, err))
+			continue
+		}
+
+		if len(ds.points) == 0 {
+			for _, p := range r.SanityCheck() {
+				problems = append(problems, name+": "+p)
+			}
+
+			continue
+		}
+
+		for _, p := range ds.points {
+			loc, err := r.reverseGeocode(pointFromCoord(p.point))
+			if err != nil && !errors.Is(err, ErrLocationNotFound) {
+				problems = append(problems, fmt.Sprintf("%s: %s: %s", name, p.name, err))
+				continue
+			}
+
+			if got := formatFields[ds.field](loc); got != p.expected {
+				problems = append(problems, fmt.Sprintf("%s: %s %v: expected %s %q, got %q",
+					name, p.name, p.point, ds.field, p.expected, got))
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return fmt.Errorf("rgeo self test failed: %s", strings.Join(problems, "; "))
+	}
+
+	return nil
+}
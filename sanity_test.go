@@ -0,0 +1,90 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+func TestSanityCheck(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, twoSquaresGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	problems := r.SanityCheck(
+		ReferencePoint{Name: "west", Point: orb.Point{0.5, 0.5}, Country: "West"},
+		ReferencePoint{Name: "sea", Point: orb.Point{1.5, 0.5}, Country: ""},
+		ReferencePoint{Name: "wrong", Point: orb.Point{2.5, 0.5}, Country: "West"},
+	)
+
+	expected := []string{`wrong [2.5 0.5]: expected "West", got "East"`}
+	if diff := deep.Equal(expected, problems); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestSanityCheck_Datasets(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test (sanity check) in short mode")
+	}
+
+	for _, dataset := range []func() []byte{Countries110, Countries10, Provinces10} {
+		dataset := dataset
+		t.Run(getFunctionName(dataset), func(t *testing.T) {
+			r, err := New(dataset)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for _, p := range r.SanityCheck() {
+				t.Error(p)
+			}
+		})
+	}
+}
+
+func TestSelfTest(t *testing.T) {
+	if testing.Short() {
+		t.Skip("Skipping integration test (self test) in short mode")
+	}
+
+	if err := SelfTest(); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestSelfTestDatasets(t *testing.T) {
+	// Every bundled dataset should be checked, and every field should be a
+	// Format placeholder.
+	checked := make(map[string]bool)
+	for _, ds := range selfTestDatasets {
+		checked[getFunctionName(ds.dataset)] = true
+
+		if _, ok := formatFields[ds.field]; len(ds.points) > 0 && !ok {
+			t.Errorf("%s: unknown field %q", getFunctionName(ds.dataset), ds.field)
+		}
+	}
+
+	for _, dataset := range []func() []byte{Countries110, Countries10, Provinces10, US_Counties10, Cities10} {
+		if !checked[getFunctionName(dataset)] {
+			t.Errorf("%s isn't checked by SelfTest", getFunctionName(dataset))
+		}
+	}
+}
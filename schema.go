@@ -0,0 +1,97 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"sync"
+)
+
+// locationDescriptions describe each Location field in the schema, by field
+// name. They follow the doc comments on Location, TestLocationSchema checks
+// that every field has one.
+var locationDescriptions = map[string]string{
+	"Country":            "Commonly used country name",
+	"CountryLong":        "Formal name of country",
+	"Sovereign":          "Name of the sovereign state, this differs from country for dependent territories, e.g. Puerto Rico belongs to the United States of America",
+	"CountryCode2":       "ISO 3166-1 alpha-2 code",
+	"CountryCode3":       "ISO 3166-1 alpha-3 code",
+	"CountryCodeNumeric": `ISO 3166-1 numeric code, e.g. "840" for the United States of America`,
+	"CallingCode":        `E.164 calling code, e.g. "+44"`,
+	"CurrencyCode":       `ISO 4217 currency code, e.g. "GBP"`,
+	"Continent":          "Continent name",
+	"Region":             "UN region name",
+	"SubRegion":          "UN subregion name",
+	"RegionCode":         `UN M49 code of the region, e.g. "150" for Europe`,
+	"SubRegionCode":      `UN M49 code of the subregion, e.g. "154" for Northern Europe`,
+	"Province":           "Province, state or other first level administrative division name",
+	"ProvinceCode":       "ISO 3166-2 code",
+	"County":             "County or other second level administrative division name",
+	"CountyCode":         `Natural Earth admin-2 code, e.g. "USA-53065", for US counties the part after the dash is the FIPS code`,
+	"City":               "City or urban area name",
+	"FeatureID":          "Identifier of the matched feature from the dataset, for joining results back to the source data, e.g. Natural Earth's NE_ID",
+}
+
+var (
+	locationSchema     []byte
+	locationSchemaOnce sync.Once
+)
+
+// LocationSchema returns a JSON Schema describing the JSON encoding of
+// Location, for documenting APIs built on rgeo. It's built from the Location
+// struct, so it always has the same fields, each one a string with a
+// description. Every field is optional as empty fields are left out of the
+// JSON.
+func LocationSchema() []byte {
+	locationSchemaOnce.Do(func() {
+		type property struct {
+			Type        string `json:"type"`
+			Description string `json:"description"`
+		}
+
+		props := make(map[string]property)
+
+		t := reflect.TypeOf(Location{})
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			props[name] = property{Type: "string", Description: locationDescriptions[f.Name]}
+		}
+
+		locationSchema, _ = json.MarshalIndent(struct {
+			Schema               string              `json:"$schema"`
+			Title                string              `json:"title"`
+			Description          string              `json:"description"`
+			Type                 string              `json:"type"`
+			Properties           map[string]property `json:"properties"`
+			AdditionalProperties bool                `json:"additionalProperties"`
+		}{
+			Schema:               "https://json-schema.org/draft/2020-12/schema",
+			Title:                "Location",
+			Description:          "A reverse geocoded location from github.com/sams96/rgeo",
+			Type:                 "object",
+			Properties:           props,
+			AdditionalProperties: false,
+		}, "", "\t")
+	})
+
+	ret := make([]byte, len(locationSchema))
+	copy(ret, locationSchema)
+
+	return ret
+}
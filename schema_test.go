@@ -0,0 +1,82 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestLocationSchema(t *testing.T) {
+	var schema struct {
+		Type       string `json:"type"`
+		Properties map[string]struct {
+			Type        string `json:"type"`
+			Description string `json:"description"`
+		} `json:"properties"`
+	}
+	if err := json.Unmarshal(LocationSchema(), &schema); err != nil {
+		t.Fatal(err)
+	}
+
+	if schema.Type != "object" {
+		t.Errorf("expected type object, got: %s", schema.Type)
+	}
+
+	// Every field in the JSON encoding of a full Location is in the schema,
+	// with a description.
+	full := Location{}
+	v := reflect.ValueOf(&full).Elem()
+	for i := 0; i < v.NumField(); i++ {
+		v.Field(i).SetString("x")
+	}
+
+	b, err := json.Marshal(full)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var fields map[string]string
+	if err := json.Unmarshal(b, &fields); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(fields) != len(schema.Properties) {
+		t.Errorf("expected %d properties, got: %d", len(fields), len(schema.Properties))
+	}
+	for name := range fields {
+		p, ok := schema.Properties[name]
+		if !ok {
+			t.Errorf("%s: missing from schema", name)
+			continue
+		}
+		if p.Type != "string" || p.Description == "" {
+			t.Errorf("%s: expected string with description, got: %+v", name, p)
+		}
+	}
+
+	if len(locationDescriptions) != v.NumField() {
+		t.Errorf("expected %d descriptions, got: %d", v.NumField(), len(locationDescriptions))
+	}
+
+	// The result can be modified without changing later calls.
+	s := LocationSchema()
+	s[0] = 'x'
+	if LocationSchema()[0] == 'x' {
+		t.Error("schema shared between calls")
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/paulmach/orb"
+)
+
+// Segment is a stretch of a trajectory spent in one country, returned by
+// Segments.
+type Segment struct {
+	// Location of the first point in the segment, this is the empty Location
+	// for points that weren't found in any country.
+	Location Location
+
+	// Times of the first and last points in the segment.
+	Start time.Time
+	End   time.Time
+}
+
+// Segments reverse geocodes a trajectory of timestamped points and coalesces
+// consecutive points in the same country into Segments. points[i] is taken to
+// be at times[i], so the slices must be the same length, and times should be in
+// order.
+//
+// Points are compared on the Country field only, so moving between provinces or
+// cities of the same country doesn't start a new segment. Points that aren't in
+// any country (ErrLocationNotFound) break the segment they interrupt and are
+// coalesced into segments of their own with an empty Location, so time spent
+// at sea still shows up in the result.
+func (r *Rgeo) Segments(points []orb.Point, times []time.Time) ([]Segment, error) {
+	if len(points) != len(times) {
+		return nil, fmt.Errorf("got %d points but %d times", len(points), len(times))
+	}
+
+	var segs []Segment
+	for i, p := range points {
+		loc, err := r.reverseGeocode(pointFromCoord(p))
+		if err != nil && !errors.Is(err, ErrLocationNotFound) {
+			return nil, fmt.Errorf("point %d: %w", i, err)
+		}
+
+		if n := len(segs); n > 0 && sameSegment(segs[n-1].Location, loc) {
+			segs[n-1].End = times[i]
+			continue
+		}
+
+		segs = append(segs, Segment{Location: loc, Start: times[i], End: times[i]})
+	}
+
+	return segs, nil
+}
+
+// sameSegment reports whether two Locations belong in the same Segment.
+func sameSegment(a, b Location) bool {
+	if a == (Location{}) || b == (Location{}) {
+		return a == b
+	}
+
+	return a.Country == b.Country
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+// twoSquaresGeo is two neighbouring 1x1 degree squares, with a gap of sea
+// between them.
+const twoSquaresGeo = `{
+	"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"ADMIN":"West","ISO_A3":"WST"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}},
+			{"type":"Feature",
+			"properties":{"ADMIN":"East","ISO_A3":"EST"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[2,0],[3,0],[3,1],[2,1],[2,0]]]}}
+		]
+	}`
+
+func TestSegments(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, twoSquaresGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	west := Location{Country: "West", CountryCode3: "WST"}
+	east := Location{Country: "East", CountryCode3: "EST"}
+	t0 := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	at := func(h int) time.Time { return t0.Add(time.Duration(h) * time.Hour) }
+
+	points := []orb.Point{{0.2, 0.5}, {0.8, 0.5}, {1.5, 0.5}, {2.5, 0.5}, {2.6, 0.5}, {0.5, 0.5}}
+	times := []time.Time{at(0), at(1), at(2), at(3), at(4), at(5)}
+
+	expected := []Segment{
+		{Location: west, Start: at(0), End: at(1)},
+		{Location: Location{}, Start: at(2), End: at(2)},
+		{Location: east, Start: at(3), End: at(4)},
+		{Location: west, Start: at(5), End: at(5)},
+	}
+
+	segs, err := r.Segments(points, times)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(expected, segs); diff != nil {
+		t.Error(diff)
+	}
+
+	if _, err := r.Segments(points, times[1:]); err == nil {
+		t.Error("expected error for mismatched lengths")
+	}
+}
@@ -0,0 +1,46 @@
+//go:build go1.23
+
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"iter"
+
+	"github.com/paulmach/orb"
+)
+
+// ReverseGeocodeSeq returns an iterator which reverse geocodes each point from
+// points as it's reached, yielding the point with its Result. Nothing is read
+// from points until the iterator is used, and only one point is held at a
+// time, so memory use stays flat for sources of any size, e.g. a file being
+// decoded line by line. Stopping the iteration early stops reading points.
+//
+// It doesn't parallelise internally, points are geocoded one at a time on the
+// goroutine ranging over the iterator and yielded in the order they come in.
+// The index lookup is done under a package level lock anyway (see Pool), so to
+// overlap the rest of the work split the points between goroutines each with
+// their own iterator.
+//
+// It needs Go 1.23 or later, for the iter package.
+func (r *Rgeo) ReverseGeocodeSeq(points iter.Seq[orb.Point]) iter.Seq2[orb.Point, Result] {
+	return func(yield func(orb.Point, Result) bool) {
+		points(func(p orb.Point) bool {
+			loc, err := r.ReverseGeocode(p)
+			return yield(p, Result{Location: loc, Err: err})
+		})
+	}
+}
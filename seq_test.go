@@ -0,0 +1,75 @@
+//go:build go1.23
+
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+func TestReverseGeocodeSeq(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, twoSquaresGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	in := []orb.Point{{0.5, 0.5}, {5, 5}, {2.5, 0.5}, {0.6, 0.6}}
+
+	// read counts the points taken from the source, to check it's read lazily.
+	read := 0
+	points := func(yield func(orb.Point) bool) {
+		for _, p := range in {
+			read++
+			if !yield(p) {
+				return
+			}
+		}
+	}
+
+	var got []Result
+	for p, res := range r.ReverseGeocodeSeq(points) {
+		if p != in[len(got)] {
+			t.Errorf("expected point %v, got %v", in[len(got)], p)
+		}
+		got = append(got, res)
+		if len(got) == 3 {
+			break
+		}
+	}
+
+	if read != 3 {
+		t.Errorf("expected 3 points to be read, got %d", read)
+	}
+
+	expected := []Location{
+		{Country: "West", CountryCode3: "WST"},
+		{},
+		{Country: "East", CountryCode3: "EST"},
+	}
+	for i, res := range got {
+		if diff := deep.Equal(expected[i], res.Location); diff != nil {
+			t.Error(i, diff)
+		}
+	}
+	if !errors.Is(got[1].Err, ErrLocationNotFound) {
+		t.Errorf("expected error: %s\n got: %s\n", ErrLocationNotFound, got[1].Err)
+	}
+}
@@ -0,0 +1,46 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/simplify"
+)
+
+// ReverseGeocodeWithSimplifiedGeometry works like ReverseGeocodeWithGeometry,
+// but simplifies the returned geometry with the Douglas-Peucker algorithm
+// first, which is useful for sending lighter polygons to be drawn on a map.
+// tolerance is in degrees, as the simplification is done on the raw
+// coordinates, so something like 0.01 (about 1km at the equator) works for a
+// country on a web map.
+//
+// The simplification only applies to the returned copy of the geometry, lookups
+// always use the full resolution shapes. If a polygon would be simplified away
+// entirely the full geometry is returned, those are small anyway.
+func (r *Rgeo) ReverseGeocodeWithSimplifiedGeometry(loc orb.Point, dataset string, tolerance float64) (LocationWithGeometry, error) {
+	res, err := r.ReverseGeocodeWithGeometry(loc, dataset)
+	if err != nil || tolerance <= 0 {
+		return res, err
+	}
+
+	// The simplifier works in place, so copy the geometry to keep the stored
+	// one intact.
+	if g := simplify.DouglasPeucker(tolerance).Simplify(orb.Clone(res.Geometry)); g != nil {
+		res.Geometry = g
+	}
+
+	return res, nil
+}
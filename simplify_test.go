@@ -0,0 +1,76 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+// countPoints returns the number of points in a Polygon or MultiPolygon.
+func countPoints(g orb.Geometry) (n int) {
+	mp, err := MergeGeometries([]orb.Geometry{g})
+	if err != nil {
+		return 0
+	}
+
+	for _, p := range mp {
+		for _, ring := range p {
+			n += len(ring)
+		}
+	}
+
+	return n
+}
+
+func TestReverseGeocodeWithSimplifiedGeometry(t *testing.T) {
+	r, err := New(Countries110)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dataset := getFunctionName(Countries110)
+	oslo := orb.Point{10.75, 59.91}
+
+	full, err := r.ReverseGeocodeWithGeometry(oslo, dataset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := countPoints(full.Geometry)
+
+	simple, err := r.ReverseGeocodeWithSimplifiedGeometry(oslo, dataset, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := deep.Equal(full.Location, simple.Location); diff != nil {
+		t.Error(diff)
+	}
+
+	if n := countPoints(simple.Geometry); n == 0 || n >= before {
+		t.Errorf("expected fewer than %d points, got %d", before, n)
+	}
+
+	again, err := r.ReverseGeocodeWithGeometry(oslo, dataset)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := countPoints(again.Geometry); n != before {
+		t.Errorf("stored geometry changed from %d to %d points", before, n)
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"math"
+
+	"github.com/golang/geo/s2"
+)
+
+// WithCoarserSnapping fills in the coarser fields missing from a result when
+// the datasets' coastlines don't match, e.g. a point just off the coast of
+// Countries110 but inside a more detailed province dataset gives a Province
+// with no Country. With it, if a coordinate is in a shape of one level (see
+// AdminLevel) but nothing it's in has the fields of a coarser level, the
+// nearest shape with those fields is used for them, from each dataset that has
+// no shape containing the coordinate, as long as it's within toleranceMeters.
+// Only the missing fields are taken from the nearby shapes, and the FeatureID
+// stays the one of the containing shapes.
+//
+// It's used by ReverseGeocode, and so by ReverseGeocodeOrNearest and Pool, but
+// not by the other methods. It's off by default, and a toleranceMeters of 0 or
+// less turns it off. Distances are measured as in NearestLocation.
+func WithCoarserSnapping(toleranceMeters float64) Option {
+	return func(o *options) {
+		if toleranceMeters <= 0 || math.IsNaN(toleranceMeters) {
+			toleranceMeters = 0
+		}
+
+		o.snapMeters = toleranceMeters
+	}
+}
+
+// snapCoarser fills in the fields of l, the combined Location of the shapes in
+// res which contain p, that are missing for levels coarser than the finest
+// of those shapes, from the nearest shapes in the other datasets.
+func (r *Rgeo) snapCoarser(p s2.Point, res []s2.Shape, l Location) Location {
+	finest := LevelCountry
+	present := make(map[string]bool)
+	for _, shape := range res {
+		if level := r.locs[shape].adminLevel(); level > finest {
+			finest = level
+		}
+
+		present[r.shapeDataset(shape)] = true
+	}
+
+	var missing Field
+	for level := LevelCountry; level < finest; level++ {
+		if l.Fields()&levelFields[level] == 0 {
+			missing |= levelFields[level]
+		}
+	}
+
+	if missing == 0 {
+		return l
+	}
+
+	limit := chordAngleFromMeters(r.opts.snapMeters)
+
+	var snapped []s2.Shape
+	for _, name := range r.datasets {
+		if present[name] {
+			continue
+		}
+
+		shpGeoms := r.geoms[name]
+		keep := func(id int32) bool {
+			shape := r.index.Shape(id)
+			if _, ok := shpGeoms[shape]; !ok {
+				return false
+			}
+
+			return r.locs[shape].Fields()&missing != 0
+		}
+
+		if ref, _, ok := r.edgeGrid().nearestEdge(p, limit, keep); ok {
+			snapped = append(snapped, r.index.Shape(ref.shape))
+		}
+	}
+
+	if len(snapped) == 0 {
+		return l
+	}
+
+	return l.fill(r.combineLocations(snapped).only(missing))
+}
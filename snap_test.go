@@ -0,0 +1,124 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+func TestWithCoarserSnapping(t *testing.T) {
+	// The province sticks out 0.1 degrees (about 11km) past the coast of the
+	// country, and the town sticks out past both.
+	countryGeo := `{
+		"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"ADMIN":"Land","ISO_A3":"LND","NE_ID":1},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}}
+		]
+	}`
+	provinceGeo := `{
+		"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"name":"Coast","ne_id":2},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0.5,0],[1.1,0],[1.1,1],[0.5,1],[0.5,0]]]}},
+			{"type":"Feature",
+			"properties":{"name":"Island","ne_id":3},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[3,0],[4,0],[4,1],[3,1],[3,0]]]}}
+		]
+	}`
+	cityGeo := `{
+		"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"name_conve":"Port"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[1,0.4],[1.3,0.4],[1.3,0.6],[1,0.6],[1,0.4]]]}}
+		]
+	}`
+	country := func() []byte { return compressData(t, countryGeo) }
+	province := func() []byte { return compressData(t, provinceGeo) }
+	city := func() []byte { return compressData(t, cityGeo) }
+	datasets := []func() []byte{country, province, city}
+
+	off, err := NewWithOptions(datasets)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	on, err := NewWithOptions(datasets, WithCoarserSnapping(25000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		r        *Rgeo
+		in       orb.Point
+		expected Location
+	}{
+		{
+			name:     "Off",
+			r:        off,
+			in:       orb.Point{1.05, 0.2},
+			expected: Location{Province: "Coast", FeatureID: "2"},
+		},
+		{
+			name:     "Inside",
+			r:        on,
+			in:       orb.Point{0.7, 0.2},
+			expected: Location{Country: "Land", CountryCode3: "LND", Province: "Coast", FeatureID: "2"},
+		},
+		{
+			name:     "Snapped",
+			r:        on,
+			in:       orb.Point{1.05, 0.2},
+			expected: Location{Country: "Land", CountryCode3: "LND", Province: "Coast", FeatureID: "2"},
+		},
+		{
+			name:     "SnappedTwoLevels",
+			r:        on,
+			in:       orb.Point{1.2, 0.5},
+			expected: Location{Country: "Land", CountryCode3: "LND", Province: "Coast", City: "Port"},
+		},
+		{
+			name:     "BeyondTolerance",
+			r:        on,
+			in:       orb.Point{3.5, 0.5},
+			expected: Location{Province: "Island", FeatureID: "3"},
+		},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			loc, err := test.r.ReverseGeocode(test.in)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := deep.Equal(test.expected, loc); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}
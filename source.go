@@ -0,0 +1,168 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+// PolygonSource gives the rings of one or more polygons, so that geometry from
+// any library (go-geom, orb, etc.) can be loaded with NewFromSources without
+// converting it to orb first. It only needs a small adapter over the library's
+// own types, or Coordinates can be used for raw coordinates.
+//
+// Rings are given as [longitude, latitude] pairs in degrees, closed like in
+// GeoJSON, so the last point is the same as the first. The first ring of each
+// polygon is its exterior and the rest are holes. They can be wound either way,
+// as with GeoJSON, unless the RequireRFC7946Winding option is used.
+type PolygonSource interface {
+	// NumPolygons returns the number of polygons.
+	NumPolygons() int
+
+	// NumRings returns the number of rings in the given polygon.
+	NumRings(polygon int) int
+
+	// Ring returns the points of the given ring of the given polygon.
+	Ring(polygon, ring int) [][2]float64
+}
+
+// Coordinates is a PolygonSource for raw coordinates, nested in the same way
+// as the coordinates of a GeoJSON MultiPolygon: polygons, then rings, then
+// [longitude, latitude] points.
+type Coordinates [][][][2]float64
+
+// NumPolygons implements PolygonSource.
+func (c Coordinates) NumPolygons() int { return len(c) }
+
+// NumRings implements PolygonSource.
+func (c Coordinates) NumRings(polygon int) int { return len(c[polygon]) }
+
+// Ring implements PolygonSource.
+func (c Coordinates) Ring(polygon, ring int) [][2]float64 { return c[polygon][ring] }
+
+// orbPolygons is the PolygonSource used for orb geometries, which is what
+// everything loaded from GeoJSON goes through.
+type orbPolygons orb.MultiPolygon
+
+func (o orbPolygons) NumPolygons() int         { return len(o) }
+func (o orbPolygons) NumRings(polygon int) int { return len(o[polygon]) }
+
+func (o orbPolygons) Ring(polygon, ring int) [][2]float64 {
+	r := o[polygon][ring]
+	pts := make([][2]float64, len(r))
+	for i, p := range r {
+		pts[i] = p
+	}
+
+	return pts
+}
+
+// orbSource returns the PolygonSource for an orb Polygon or MultiPolygon.
+func orbSource(g orb.Geometry) (PolygonSource, error) {
+	switch t := g.(type) {
+	case orb.Polygon:
+		return orbPolygons{t}, nil
+	case orb.MultiPolygon:
+		return orbPolygons(t), nil
+	default:
+		return nil, errors.New("needs Polygon or MultiPolygon")
+	}
+}
+
+// sourcePolygon returns the given polygon from src as an orb Polygon, which
+// the ring checks and conversion work on. Polygons from orbPolygons are used
+// as they are, without copying.
+func sourcePolygon(src PolygonSource, i int) orb.Polygon {
+	if o, ok := src.(orbPolygons); ok {
+		return o[i]
+	}
+
+	p := make(orb.Polygon, src.NumRings(i))
+	for j := range p {
+		pts := src.Ring(i, j)
+		r := make(orb.Ring, len(pts))
+		for k, pt := range pts {
+			r[k] = pt
+		}
+		p[j] = r
+	}
+
+	return p
+}
+
+// geometryFromSource returns the polygons from src as an orb MultiPolygon, which
+// is how they are returned by GetGeometry and the other methods giving the
+// geometry of the loaded shapes.
+func geometryFromSource(src PolygonSource) orb.Geometry {
+	if o, ok := src.(orbPolygons); ok {
+		return orb.MultiPolygon(o)
+	}
+
+	mp := make(orb.MultiPolygon, src.NumPolygons())
+	for i := range mp {
+		mp[i] = sourcePolygon(src, i)
+	}
+
+	return mp
+}
+
+// SourceFeature is a feature for NewFromSources, its Properties are read in the
+// same way as the properties of a GeoJSON feature.
+type SourceFeature struct {
+	Geometry   PolygonSource
+	Properties map[string]interface{}
+}
+
+// NewFromSources returns an Rgeo struct built from features whose geometry
+// comes from a PolygonSource rather than GeoJSON. Otherwise it is the same as
+// NewFromFeatureCollection, the name is used as the dataset name and can't be
+// empty. The geometry of each feature is kept as an orb MultiPolygon, which is
+// what GetGeometry returns for them.
+func NewFromSources(features []SourceFeature, name string, opts ...Option) (*Rgeo, error) {
+	if name == "" {
+		return nil, errors.New("missing parameter: dataset name")
+	}
+
+	ret := newRgeo(opts...)
+	shpGeoms := ret.datasetGeoms(name, len(features))
+
+	strs := make(interner)
+
+	for i, f := range features {
+		if f.Geometry == nil {
+			return nil, fmt.Errorf("feature %d: nil geometry", i)
+		}
+
+		err := ret.addFeature(geometryFromSource(f.Geometry), f.Properties, name, shpGeoms, strs)
+		if err != nil {
+			return nil, err
+		}
+
+		ret.reportProgress(name, i+1, len(features))
+	}
+
+	if len(features) == 0 {
+		ret.reportProgress(name, 0, 0)
+	}
+
+	ret.query = s2.NewContainsPointQuery(ret.index, s2.VertexModelOpen)
+
+	return ret, nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+// flatPolygon is a PolygonSource for a single polygon stored as flat
+// coordinates with ring ends, like a go-geom Polygon.
+type flatPolygon struct {
+	coords []float64
+	ends   []int
+}
+
+func (f flatPolygon) NumPolygons() int         { return 1 }
+func (f flatPolygon) NumRings(polygon int) int { return len(f.ends) }
+
+func (f flatPolygon) Ring(polygon, ring int) [][2]float64 {
+	start := 0
+	if ring > 0 {
+		start = f.ends[ring-1]
+	}
+
+	var pts [][2]float64
+	for i := start; i < f.ends[ring]; i += 2 {
+		pts = append(pts, [2]float64{f.coords[i], f.coords[i+1]})
+	}
+
+	return pts
+}
+
+func TestNewFromSources(t *testing.T) {
+	// A 2x2 square with a 1x1 hole, and a 1x1 square to the east of it.
+	withHole := flatPolygon{
+		coords: []float64{
+			0, 0, 2, 0, 2, 2, 0, 2, 0, 0,
+			0.5, 0.5, 0.5, 1.5, 1.5, 1.5, 1.5, 0.5, 0.5, 0.5,
+		},
+		ends: []int{10, 20},
+	}
+	east := Coordinates{{{{3, 0}, {4, 0}, {4, 1}, {3, 1}, {3, 0}}}}
+
+	r, err := NewFromSources([]SourceFeature{
+		{Geometry: withHole, Properties: map[string]interface{}{"ISO_A3": "HOL"}},
+		{Geometry: east, Properties: map[string]interface{}{"ISO_A3": "EST"}},
+	}, "sources")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		in       orb.Point
+		expected Location
+		err      error
+	}{
+		{orb.Point{0.25, 0.25}, Location{CountryCode3: "HOL"}, nil},
+		{orb.Point{1, 1}, Location{}, ErrLocationNotFound},
+		{orb.Point{3.5, 0.5}, Location{CountryCode3: "EST"}, nil},
+	}
+
+	for _, test := range tests {
+		loc, err := r.ReverseGeocode(test.in)
+		if !errors.Is(err, test.err) {
+			t.Errorf("%v: expected error: %v\n got: %v\n", test.in, test.err, err)
+		}
+		if diff := deep.Equal(test.expected, loc); diff != nil {
+			t.Error(test.in, diff)
+		}
+	}
+
+	g, err := r.GetGeometry(orb.Point{3.5, 0.5}, "sources")
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := orb.MultiPolygon{{{{3, 0}, {4, 0}, {4, 1}, {3, 1}, {3, 0}}}}
+	if diff := deep.Equal(orb.Geometry(expected), g); diff != nil {
+		t.Error(diff)
+	}
+}
+
+func TestNewFromSources_Errors(t *testing.T) {
+	if _, err := NewFromSources(nil, ""); err == nil {
+		t.Error("expected error for empty dataset name")
+	}
+
+	_, err := NewFromSources([]SourceFeature{{}}, "sources")
+	if err == nil {
+		t.Error("expected error for nil geometry")
+	}
+
+	open := Coordinates{{{{0, 0}, {1, 0}, {1, 1}, {0, 1}}}}
+	_, err = NewFromSources([]SourceFeature{{Geometry: open}}, "sources")
+	if err == nil {
+		t.Error("expected error for unclosed ring")
+	}
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"time"
+
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+// validity is the period a dataset's borders are valid for, a zero time
+// leaves that end open.
+type validity struct {
+	from, to time.Time
+}
+
+// contains reports whether t is in the period, which includes from but not
+// to.
+func (v validity) contains(t time.Time) bool {
+	return (v.from.IsZero() || !t.Before(v.from)) && (v.to.IsZero() || t.Before(v.to))
+}
+
+// WithDatasetValidity sets the period a dataset passed to NewWithOptions is
+// valid for, for historical boundaries, e.g. a dataset of the borders from
+// 1949 to 1990 for which ReverseGeocodeAt gives Germany's two states. The
+// period includes from but not to, and a zero time leaves that end open, so a
+// dataset of the current borders would have a zero to. to has to be after
+// from, otherwise the dataset is never valid.
+//
+// Datasets without a validity period are valid at all times. The period only
+// affects ReverseGeocodeAt, every other method uses all of the loaded shapes.
+func WithDatasetValidity(dataset func() []byte, from, to time.Time) Option {
+	return func(o *options) {
+		if o.validity == nil {
+			o.validity = make(map[string]validity)
+		}
+
+		o.validity[getFunctionName(dataset)] = validity{from: from, to: to}
+	}
+}
+
+// ReverseGeocodeAt works like ReverseGeocode, but only uses the shapes from
+// datasets which were valid at time t, as set with WithDatasetValidity.
+//
+// If the validity periods of datasets overlap, and t is in more than one of
+// them, the shapes containing the point from all of those datasets are
+// combined just as they are by ReverseGeocode: each field comes from the first
+// shape that has it, in the order set by WithDatasetPriority and
+// WithShapePreference. So to have a dataset of changed borders override a
+// base dataset for the years it covers, give it a higher priority.
+func (r *Rgeo) ReverseGeocodeAt(loc orb.Point, t time.Time) (Location, error) {
+	res := r.containingShapes(pointFromCoord(loc))
+
+	kept := make([]s2.Shape, 0, len(res))
+	for _, shape := range res {
+		if v, ok := r.validity[shape]; !ok || v.contains(t) {
+			kept = append(kept, shape)
+		}
+	}
+
+	if len(kept) == 0 {
+		return Location{}, ErrLocationNotFound
+	}
+
+	return r.combineLocations(kept), nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+func TestReverseGeocodeAt(t *testing.T) {
+	unifiedGeo := `{
+		"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"ADMIN":"Unified","ISO_A3":"UNI"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,0],[3,0],[3,1],[0,1],[0,0]]]}}
+		]
+	}`
+	current := func() []byte { return compressData(t, unifiedGeo) }
+	divided := func() []byte { return compressData(t, twoSquaresGeo) }
+	gone := func() []byte { return compressData(t, squareGeo) }
+
+	year := func(y int) time.Time { return time.Date(y, 1, 1, 0, 0, 0, 0, time.UTC) }
+
+	r, err := NewWithOptions([]func() []byte{current, divided, gone},
+		WithDatasetValidity(current, year(1990), time.Time{}),
+		WithDatasetValidity(divided, year(1949), year(1990)),
+		WithDatasetPriority(divided, 1),
+		WithDatasetValidity(gone, time.Time{}, year(1900)),
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	unified := Location{Country: "Unified", CountryCode3: "UNI"}
+	west := Location{Country: "West", CountryCode3: "WST"}
+
+	tests := []struct {
+		name     string
+		in       orb.Point
+		at       time.Time
+		expected Location
+		err      error
+	}{
+		{"Before", orb.Point{0.5, 0.5}, year(1948), Location{}, ErrLocationNotFound},
+		{"Divided", orb.Point{0.5, 0.5}, year(1960), west, nil},
+		{"Between", orb.Point{1.5, 0.5}, year(1960), Location{}, ErrLocationNotFound},
+		{"EndExcluded", orb.Point{0.5, 0.5}, year(1990), unified, nil},
+		{"Now", orb.Point{1.5, 0.5}, year(2020), unified, nil},
+		{"OpenStart", orb.Point{0.5, 52.5}, year(1000), Location{CountryCode3: "TST"}, nil},
+		{"Gone", orb.Point{0.5, 52.5}, year(1900), Location{}, ErrLocationNotFound},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			loc, err := r.ReverseGeocodeAt(test.in, test.at)
+			if !errors.Is(err, test.err) {
+				t.Errorf("expected error: %v\n got: %v\n", test.err, err)
+			}
+			if diff := deep.Equal(test.expected, loc); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+
+	// ReverseGeocode ignores the validity, so the divided dataset wins on
+	// priority.
+	loc, err := r.ReverseGeocode(orb.Point{0.5, 0.5})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(west, loc); diff != nil {
+		t.Error(diff)
+	}
+}
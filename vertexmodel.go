@@ -0,0 +1,117 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+// ReverseGeocodeVM works like ReverseGeocode, but with the given s2 vertex
+// model rather than s2.VertexModelOpen, which ReverseGeocode always uses. With
+// s2.VertexModelClosed a point on one of a shape's vertices is inside it, so a
+// point on a vertex shared by neighbouring countries gives all of them,
+// combined as in ReverseGeocode, where with the open model it gives none. With
+// s2.VertexModelSemiOpen it's in exactly one of them. The model only changes
+// which shapes contain their vertices, points along an edge are in exactly one
+// shape either way, use ReverseGeocodeBorder to find all of the shapes along
+// a border. Like ReverseGeocode, a coordinate that isn't in any shape gives
+// the Location from WithFallbackLocation if it's set.
+//
+// A query is made for each model the first time it's used, and kept for later
+// calls. Making one is cheap, as it only holds an iterator over the index,
+// which is built once in New and shared by all of them.
+func (r *Rgeo) ReverseGeocodeVM(loc orb.Point, vm s2.VertexModel) (Location, error) {
+	if vm != s2.VertexModelOpen && vm != s2.VertexModelSemiOpen && vm != s2.VertexModelClosed {
+		return Location{}, fmt.Errorf("invalid vertex model: %d", vm)
+	}
+
+	res := r.vmContainingShapes(pointFromCoord(loc), vm)
+	if len(res) == 0 {
+		return r.withFallback(Location{}, ErrLocationNotFound)
+	}
+
+	return r.combineLocations(res), nil
+}
+
+// ErrTileOverlap is returned by ReverseGeocodeTile for a point inside more
+// than one shape of the dataset, which isn't possible if its shapes tile the
+// area.
+var ErrTileOverlap = errors.New("point is in more than one shape")
+
+// ReverseGeocodeTile returns the Location of the one shape in the given
+// dataset that contains the coordinate, for checking that the shapes of a
+// dataset tile an area without gaps or overlaps. A point in none of them gives
+// ErrLocationNotFound, which is a gap, and a point in more than one gives an
+// error wrapping ErrTileOverlap.
+//
+// Unlike ReverseGeocode, points on the boundaries between shapes are always in
+// exactly one of them. It uses s2.VertexModelSemiOpen, so that a vertex shared
+// by several shapes is in exactly one of them, and points along an edge are in
+// exactly one of the two shapes either side of it with any vertex model. Which
+// one is decided by s2's symbolic perturbation rules, which only depend on the
+// coordinates of the point and the edge, so it's the same every time
+// regardless of the order the shapes were loaded in, and the same for every
+// point along the edge. This only holds where neighbouring shapes share the
+// same vertices, a vertex of one shape part way along the edge of another can
+// leave a point in both or neither of them.
+func (r *Rgeo) ReverseGeocodeTile(loc orb.Point, dataset string) (Location, error) {
+	shpGeoms, ok := r.geoms[dataset]
+	if !ok {
+		return Location{}, fmt.Errorf("dataset not found: %q (have %v)", dataset, r.DatasetNames())
+	}
+
+	var in []s2.Shape
+	for _, shape := range r.vmContainingShapes(pointFromCoord(loc), s2.VertexModelSemiOpen) {
+		if _, ok := shpGeoms[shape]; ok {
+			in = append(in, shape)
+		}
+	}
+
+	switch len(in) {
+	case 0:
+		return Location{}, ErrLocationNotFound
+	case 1:
+		return r.locs[in[0]], nil
+	default:
+		return Location{}, fmt.Errorf("%w: %d shapes in %q", ErrTileOverlap, len(in), dataset)
+	}
+}
+
+// vmContainingShapes returns the shapes containing p with the given vertex
+// model. A query is made for each model the first time it's used, and kept
+// for later calls.
+func (r *Rgeo) vmContainingShapes(p s2.Point, vm s2.VertexModel) []s2.Shape {
+	containsPointQueryLock.Lock()
+	defer containsPointQueryLock.Unlock()
+
+	query := r.query
+	if vm != s2.VertexModelOpen {
+		if query = r.vmQueries[vm]; query == nil {
+			if r.vmQueries == nil {
+				r.vmQueries = make(map[s2.VertexModel]*s2.ContainsPointQuery)
+			}
+
+			query = s2.NewContainsPointQuery(r.index, vm)
+			r.vmQueries[vm] = query
+		}
+	}
+
+	return query.ContainingShapes(p)
+}
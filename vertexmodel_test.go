@@ -0,0 +1,164 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+func TestReverseGeocodeVM(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, neighboursGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	west := Location{Country: "West"}
+
+	tests := []struct {
+		name     string
+		in       orb.Point
+		vm       s2.VertexModel
+		expected Location
+		err      error
+	}{
+		{"InsideOpen", orb.Point{0.5, 0.5}, s2.VertexModelOpen, west, nil},
+		{"InsideClosed", orb.Point{0.5, 0.5}, s2.VertexModelClosed, west, nil},
+		{"CornerOpen", orb.Point{0, 0}, s2.VertexModelOpen, Location{}, ErrLocationNotFound},
+		{"CornerClosed", orb.Point{0, 0}, s2.VertexModelClosed, west, nil},
+		{"SharedOpen", orb.Point{1, 0}, s2.VertexModelOpen, Location{}, ErrLocationNotFound},
+		{"SharedClosed", orb.Point{1, 0}, s2.VertexModelClosed, west, nil},
+		{"Outside", orb.Point{3, 3}, s2.VertexModelClosed, Location{}, ErrLocationNotFound},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			loc, err := r.ReverseGeocodeVM(test.in, test.vm)
+			if !errors.Is(err, test.err) {
+				t.Errorf("expected error: %v\n got: %v\n", test.err, err)
+			}
+			if diff := deep.Equal(test.expected, loc); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+
+	// Semi-open gives a shared vertex to exactly one of the shapes, so it's
+	// found whichever that is.
+	if _, err := r.ReverseGeocodeVM(orb.Point{1, 0}, s2.VertexModelSemiOpen); err != nil {
+		t.Errorf("expected a shape for the shared vertex: %v", err)
+	}
+
+	// The open model gives the same results as ReverseGeocode.
+	for _, p := range []orb.Point{{0.5, 0.5}, {1.5, 0.5}, {1, 0}} {
+		exp, expErr := r.ReverseGeocode(p)
+		got, gotErr := r.ReverseGeocodeVM(p, s2.VertexModelOpen)
+		if !errors.Is(gotErr, expErr) {
+			t.Errorf("%v: expected error: %v, got: %v", p, expErr, gotErr)
+		}
+		if diff := deep.Equal(exp, got); diff != nil {
+			t.Error(diff)
+		}
+	}
+
+	if _, err := r.ReverseGeocodeVM(orb.Point{0.5, 0.5}, s2.VertexModel(99)); err == nil {
+		t.Error("expected error for invalid vertex model")
+	}
+}
+
+// tilesGeo has four squares tiling 0..2 by 0..2, all sharing the vertex at
+// (1, 1).
+const tilesGeo = `{
+	"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"ADMIN":"SW"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,0],[1,0],[1,1],[0,1],[0,0]]]}},
+			{"type":"Feature",
+			"properties":{"ADMIN":"SE"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[1,0],[2,0],[2,1],[1,1],[1,0]]]}},
+			{"type":"Feature",
+			"properties":{"ADMIN":"NE"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[1,1],[2,1],[2,2],[1,2],[1,1]]]}},
+			{"type":"Feature",
+			"properties":{"ADMIN":"NW"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,1],[1,1],[1,2],[0,2],[0,1]]]}}
+		]
+	}`
+
+func TestReverseGeocodeTile(t *testing.T) {
+	tiles := func() []byte { return compressData(t, tilesGeo) }
+
+	r, err := New(tiles)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Every point on the shared edges and vertices is in exactly one of the
+	// shapes, and always the same one, including the vertices which
+	// ReverseGeocode gives for none of them.
+	var points []orb.Point
+	for i := 0; i <= 20; i++ {
+		f := float64(i) / 10
+		points = append(points, orb.Point{1, f}, orb.Point{f, 1})
+	}
+
+	for _, p := range points {
+		if p[0] == 0 || p[0] == 2 || p[1] == 0 || p[1] == 2 {
+			// The outside edge of the area.
+			continue
+		}
+
+		first, err := r.ReverseGeocodeTile(p, getFunctionName(tiles))
+		if err != nil {
+			t.Errorf("%v: %v", p, err)
+			continue
+		}
+
+		again, err := r.ReverseGeocodeTile(p, getFunctionName(tiles))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := deep.Equal(first, again); diff != nil {
+			t.Errorf("%v: %v", p, diff)
+		}
+	}
+
+	if _, err := r.ReverseGeocodeTile(orb.Point{3, 3}, getFunctionName(tiles)); !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("expected ErrLocationNotFound, got %v", err)
+	}
+	if _, err := r.ReverseGeocodeTile(orb.Point{0.5, 0.5}, "missing"); err == nil {
+		t.Error("expected error for missing dataset")
+	}
+
+	enclave := func() []byte { return compressData(t, enclaveGeo) }
+	overlapping, err := New(enclave)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := overlapping.ReverseGeocodeTile(orb.Point{1.5, 1.5}, getFunctionName(enclave)); !errors.Is(err, ErrTileOverlap) {
+		t.Errorf("expected ErrTileOverlap, got %v", err)
+	}
+}
@@ -0,0 +1,55 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import "github.com/paulmach/orb"
+
+// warmupPoints are queried by Warmup, spread over every continent.
+var warmupPoints = []orb.Point{
+	{-0.13, 51.5},  // London
+	{-74, 40.7},    // New York
+	{-46.6, -23.5}, // São Paulo
+	{31.2, 30},     // Cairo
+	{77.2, 28.6},   // Delhi
+	{139.7, 35.7},  // Tokyo
+	{151.2, -33.9}, // Sydney
+	{0, 0},         // Null Island, in the sea
+	{166.7, -77.8}, // McMurdo Station
+}
+
+// Warmup does the work that's otherwise left until the first query, so that
+// the first requests to a server aren't slower than the rest. It's optional,
+// everything works the same without it, and it's safe to call more than once
+// or concurrently with other queries.
+//
+// The ShapeIndex itself is already built by New, so ReverseGeocode is only
+// slightly slower the first time while memory is paged in, which running a
+// few queries here takes care of. The bigger cost is the grid of the shapes'
+// edges, which is used by everything that measures the distance from a point
+// to a border or looks for shapes near a point rather than containing it,
+// like NearestLocation, LocationsWithinRadius, Neighbors and ReverseGeocode
+// with WithCoarserSnapping. It's built the first time one of them needs it,
+// and takes around half a second with Countries10 and Provinces10 loaded.
+// Warmup builds it too, so if you only use ReverseGeocode and other methods
+// which just look at the shapes containing a point, you can save the memory
+// by running a few queries yourself instead.
+func (r *Rgeo) Warmup() {
+	for _, p := range warmupPoints {
+		_, _ = r.ReverseGeocode(p)
+	}
+
+	r.edgeGrid()
+}
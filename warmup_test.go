@@ -0,0 +1,49 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+)
+
+func TestWarmup(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, squareGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	r.Warmup()
+	if r.edges == nil {
+		t.Error("expected edge grid to be built")
+	}
+
+	// Calling it again, and querying after it, works as normal.
+	r.Warmup()
+
+	loc, dist, err := r.NearestLocation(orb.Point{0.5, 54})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := deep.Equal(Location{CountryCode3: "TST"}, loc); diff != nil {
+		t.Error(diff)
+	}
+	if dist <= 0 {
+		t.Errorf("expected positive distance, got: %f", dist)
+	}
+}
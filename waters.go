@@ -0,0 +1,156 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/golang/geo/s2"
+	"github.com/paulmach/orb"
+)
+
+// defaultEEZMeters is 200 nautical miles, the usual extent of an exclusive
+// economic zone.
+const defaultEEZMeters = 200 * 1852
+
+// WithEEZDistance sets how far from land IsInternationalWaters counts as a
+// country's waters, by default 200 nautical miles (370.4km). A negative
+// distance is treated as 0, which makes all water international.
+func WithEEZDistance(meters float64) Option {
+	return func(o *options) {
+		if meters < 0 || math.IsNaN(meters) {
+			meters = 0
+		}
+
+		o.eezMeters = meters
+		o.eezMetersSet = true
+	}
+}
+
+// WithEEZDataset makes IsInternationalWaters use the shapes of a dataset passed
+// to NewWithOptions as the exclusive economic zones, rather than a distance
+// from land, e.g. a GeoJSON conversion of the Marine Regions EEZ boundaries.
+// The dataset is loaded like any other, so its shapes are also returned by
+// ReverseGeocode, and it should only set the fields it's trusted for.
+func WithEEZDataset(dataset func() []byte) Option {
+	return func(o *options) {
+		o.eezDataset = getFunctionName(dataset)
+	}
+}
+
+// IsInternationalWaters reports whether loc is in international waters, that
+// is it isn't in any country, and there is no country within the exclusive
+// economic zone distance set with WithEEZDistance, 200 nautical miles by
+// default. A country is any shape with a Country, so one of the country
+// datasets has to be loaded, otherwise everywhere is international waters.
+//
+// This is only an approximation of the real zones: the distance is measured
+// from the borders in the loaded data, not from the legal baselines, and it
+// ignores the treaties and median lines used where zones overlap. The border
+// detail matters too, with Countries110 small islands are missing so the
+// waters around them come out as international. For exact results use
+// WithEEZDataset, in which case a point is in international waters if it isn't
+// in a country or in any of the dataset's shapes.
+func (r *Rgeo) IsInternationalWaters(loc orb.Point) bool {
+	p := pointFromCoord(loc)
+
+	for _, shape := range r.containingShapes(p) {
+		if r.locs[shape].Country != "" || r.isEEZShape(shape) {
+			return false
+		}
+	}
+
+	if r.opts.eezDataset != "" {
+		return true
+	}
+
+	meters := float64(defaultEEZMeters)
+	if r.opts.eezMetersSet {
+		meters = r.opts.eezMeters
+	}
+
+	keep := func(id int32) bool {
+		return r.locs[r.index.Shape(id)].Country != ""
+	}
+
+	_, _, near := r.edgeGrid().nearestEdge(p, chordAngleFromMeters(meters), keep)
+
+	return !near
+}
+
+// isEEZShape reports whether the shape is from the dataset set with
+// WithEEZDataset.
+func (r *Rgeo) isEEZShape(shape s2.Shape) bool {
+	if r.opts.eezDataset == "" {
+		return false
+	}
+
+	_, ok := r.geoms[r.opts.eezDataset][shape]
+
+	return ok
+}
+
+// IsCoastal reports whether loc is within meters of a coast, for the two cases:
+//   - loc is in a country: whether it's within meters of the border of the
+//     country it's in. The datasets don't tell coasts and land borders apart,
+//     so a point near the border with another country counts too.
+//   - loc isn't in a country: whether any country is within meters of it, so
+//     a ship a few kilometres off the coast is coastal.
+//
+// The countries are the shapes with a Country. For the first case the shape
+// containing loc with the country fields and nothing finer is used, as in
+// CountryCovering, so a point near the border between two provinces isn't
+// coastal. If only finer shapes contain loc, e.g. with just Provinces10
+// loaded, their borders are used instead and it is. Distances are measured on
+// a sphere as in NearestLocation.
+func (r *Rgeo) IsCoastal(loc orb.Point, meters float64) (bool, error) {
+	if meters < 0 || math.IsNaN(meters) {
+		return false, fmt.Errorf("invalid distance: %v", meters)
+	}
+
+	p := pointFromCoord(loc)
+
+	var countries, finer []s2.Shape
+	for _, shape := range r.containingShapes(p) {
+		switch l := r.locs[shape]; {
+		case l.Country == "":
+		case l.adminLevel() == LevelCountry:
+			countries = append(countries, shape)
+		default:
+			finer = append(finer, shape)
+		}
+	}
+	if len(countries) == 0 {
+		countries = finer
+	}
+
+	var keep func(id int32) bool
+	if len(countries) > 0 {
+		set := make(map[s2.Shape]bool, len(countries))
+		for _, shape := range countries {
+			set[shape] = true
+		}
+
+		keep = func(id int32) bool { return set[r.index.Shape(id)] }
+	} else {
+		keep = func(id int32) bool { return r.locs[r.index.Shape(id)].Country != "" }
+	}
+
+	_, _, near := r.edgeGrid().nearestEdge(p, chordAngleFromMeters(meters), keep)
+
+	return near, nil
+}
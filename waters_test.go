@@ -0,0 +1,141 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"testing"
+
+	"github.com/paulmach/orb"
+)
+
+func TestIsInternationalWaters(t *testing.T) {
+	eezGeo := `{
+		"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[-0.5,-0.5],[3.5,-0.5],[3.5,1.5],[-0.5,1.5],[-0.5,-0.5]]]}}
+		]
+	}`
+	countries := func() []byte { return compressData(t, twoSquaresGeo) }
+	eez := func() []byte { return compressData(t, eezGeo) }
+
+	byDistance, err := New(countries)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	short, err := NewWithOptions([]func() []byte{countries}, WithEEZDistance(50000))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	byDataset, err := NewWithOptions([]func() []byte{countries, eez}, WithEEZDataset(eez))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// squareGeo has no Country, so it isn't counted as land.
+	noCountries, err := New(func() []byte { return compressData(t, squareGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// One degree of latitude is about 111km.
+	tests := []struct {
+		name     string
+		r        *Rgeo
+		in       orb.Point
+		expected bool
+	}{
+		{"Land", byDistance, orb.Point{0.5, 0.5}, false},
+		{"Between", byDistance, orb.Point{1.5, 0.5}, false},
+		{"WithinDefault", byDistance, orb.Point{0.5, 4}, false},
+		{"BeyondDefault", byDistance, orb.Point{0.5, 5}, true},
+		{"BeyondShort", short, orb.Point{0.5, 2}, true},
+		{"WithinShort", short, orb.Point{0.5, 1.2}, false},
+		{"DatasetLand", byDataset, orb.Point{0.5, 0.5}, false},
+		{"InsideZone", byDataset, orb.Point{0.5, 1.3}, false},
+		{"OutsideZone", byDataset, orb.Point{0.5, 2}, true},
+		{"NoCountries", noCountries, orb.Point{0.5, 52.5}, true},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.r.IsInternationalWaters(test.in); got != test.expected {
+				t.Errorf("expected: %v, got: %v", test.expected, got)
+			}
+		})
+	}
+}
+
+func TestIsCoastal(t *testing.T) {
+	// West and East share a border, North is a province of West along its top
+	// edge.
+	westGeo := `{
+		"type":"FeatureCollection",
+		"features":[
+			{"type":"Feature",
+			"properties":{"name":"North","admin":"West"},
+			"geometry":{"type":"Polygon",
+				"coordinates":[[[0,0.9],[1,0.9],[1,1],[0,1],[0,0.9]]]}}
+		]
+	}`
+
+	r, err := New(
+		func() []byte { return compressData(t, neighboursGeo) },
+		func() []byte { return compressData(t, westGeo) },
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		in       orb.Point
+		meters   float64
+		expected bool
+	}{
+		{"Inland", orb.Point{0.5, 0.5}, 10000, false},
+		{"InlandWide", orb.Point{0.5, 0.5}, 60000, true},
+		{"NearCoast", orb.Point{0.05, 0.5}, 10000, true},
+		{"NearLandBorder", orb.Point{1.05, 0.5}, 10000, true},
+		{"NearProvinceBorder", orb.Point{0.5, 0.85}, 10000, false},
+		{"InProvince", orb.Point{0.5, 0.95}, 10000, true},
+		{"OffCoast", orb.Point{2.05, 0.5}, 10000, true},
+		{"FarOffCoast", orb.Point{2.05, 0.5}, 1000, false},
+		{"Sea", orb.Point{5, 5}, 10000, false},
+	}
+
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			got, err := r.IsCoastal(test.in, test.meters)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.expected {
+				t.Errorf("expected %v, got %v", test.expected, got)
+			}
+		})
+	}
+
+	if _, err := r.IsCoastal(orb.Point{0.5, 0.5}, -1); err == nil {
+		t.Error("expected error for negative distance")
+	}
+}
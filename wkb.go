@@ -0,0 +1,63 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"fmt"
+
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/encoding/wkb"
+)
+
+// ReverseGeocodeWKB works like ReverseGeocode, but takes the coordinate as a
+// WKB encoded point, as returned by many databases. It returns an error if the
+// WKB isn't a point.
+func (r *Rgeo) ReverseGeocodeWKB(b []byte) (Location, error) {
+	g, err := wkb.Unmarshal(b)
+	if err != nil {
+		return Location{}, fmt.Errorf("invalid WKB: %w", err)
+	}
+
+	p, ok := g.(orb.Point)
+	if !ok {
+		return Location{}, fmt.Errorf("WKB needs Point, got %s", g.GeoJSONType())
+	}
+
+	return r.ReverseGeocode(p)
+}
+
+// ReverseGeocodeWKBPolygon returns the Locations of all of the shapes which
+// intersect the given WKB encoded Polygon or MultiPolygon, in the order they
+// were loaded. Duplicate Locations are only returned once, and if nothing
+// intersects the polygon it returns ErrLocationNotFound.
+func (r *Rgeo) ReverseGeocodeWKBPolygon(b []byte) ([]Location, error) {
+	g, err := wkb.Unmarshal(b)
+	if err != nil {
+		return nil, fmt.Errorf("invalid WKB: %w", err)
+	}
+
+	p, err := polygonFromGeometry(g, options{})
+	if err != nil {
+		return nil, fmt.Errorf("bad polygon in WKB: %w", err)
+	}
+
+	shapes := r.intersectingShapes(p)
+	if len(shapes) == 0 {
+		return nil, ErrLocationNotFound
+	}
+
+	return r.uniqueLocations(shapes), nil
+}
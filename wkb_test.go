@@ -0,0 +1,109 @@
+/*
+Copyright 2020 Sam Smith
+
+Licensed under the Apache License, Version 2.0 (the "License"); you may not use
+this file except in compliance with the License.  You may obtain a copy of the
+License at
+
+   http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software distributed
+under the License is distributed on an "AS IS" BASIS, WITHOUT WARRANTIES OR
+CONDITIONS OF ANY KIND, either express or implied.  See the License for the
+specific language governing permissions and limitations under the License.
+*/
+
+package rgeo
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/go-test/deep"
+	"github.com/paulmach/orb"
+	"github.com/paulmach/orb/encoding/wkb"
+)
+
+func TestReverseGeocodeWKB(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, twoSquaresGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	loc, err := r.ReverseGeocodeWKB(wkb.MustMarshal(orb.Point{0.5, 0.5}))
+	if err != nil {
+		t.Error(err)
+	}
+	if diff := deep.Equal(Location{Country: "West", CountryCode3: "WST"}, loc); diff != nil {
+		t.Error(diff)
+	}
+
+	_, err = r.ReverseGeocodeWKB(wkb.MustMarshal(orb.Point{1.5, 0.5}))
+	if !errors.Is(err, ErrLocationNotFound) {
+		t.Errorf("expected error: %s\n got: %s\n", ErrLocationNotFound, err)
+	}
+
+	_, err = r.ReverseGeocodeWKB(wkb.MustMarshal(orb.Polygon{{{0, 0}, {1, 0}, {1, 1}, {0, 0}}}))
+	if err == nil || err.Error() != "WKB needs Point, got Polygon" {
+		t.Errorf("unexpected error: %s", err)
+	}
+
+	_, err = r.ReverseGeocodeWKB([]byte("not WKB"))
+	if err == nil {
+		t.Error("expected error for invalid WKB")
+	}
+}
+
+func TestReverseGeocodeWKBPolygon(t *testing.T) {
+	r, err := New(func() []byte { return compressData(t, twoSquaresGeo) })
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	west := Location{Country: "West", CountryCode3: "WST"}
+	east := Location{Country: "East", CountryCode3: "EST"}
+
+	testdata := []struct {
+		name     string
+		in       orb.Geometry
+		err      error
+		expected []Location
+	}{
+		{
+			name:     "both",
+			in:       orb.Polygon{{{0.5, 0.2}, {2.5, 0.2}, {2.5, 0.8}, {0.5, 0.8}, {0.5, 0.2}}},
+			expected: []Location{west, east},
+		},
+		{
+			name:     "inside one",
+			in:       orb.Polygon{{{0.2, 0.2}, {0.8, 0.2}, {0.8, 0.8}, {0.2, 0.8}, {0.2, 0.2}}},
+			expected: []Location{west},
+		},
+		{
+			name: "multipolygon",
+			in: orb.MultiPolygon{
+				{{{2.2, 0.2}, {2.8, 0.2}, {2.8, 0.8}, {2.2, 0.8}, {2.2, 0.2}}},
+				{{{5, 5}, {6, 5}, {6, 6}, {5, 6}, {5, 5}}},
+			},
+			expected: []Location{east},
+		},
+		{
+			name: "sea",
+			in:   orb.Polygon{{{1.2, 0.2}, {1.8, 0.2}, {1.8, 0.8}, {1.2, 0.8}, {1.2, 0.2}}},
+			err:  ErrLocationNotFound,
+		},
+	}
+
+	for _, test := range testdata {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			locs, err := r.ReverseGeocodeWKBPolygon(wkb.MustMarshal(test.in))
+			if !errors.Is(err, test.err) {
+				t.Errorf("expected error: %s\n got: %s\n", test.err, err)
+			}
+			if diff := deep.Equal(test.expected, locs); diff != nil {
+				t.Error(diff)
+			}
+		})
+	}
+}